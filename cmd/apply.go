@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"zonecopy/internal/domain/entity"
+	"zonecopy/internal/usecase"
+)
+
+// runApply apply 命令，将目标站点收敛到期望状态目录中定义的配置。
+func runApply(args []string) {
+	var configPath, dir string
+	var prune bool
+	fs := flag.NewFlagSet("apply", flag.ExitOnError)
+	fs.StringVar(&configPath, "config", "./config/cp.yaml", "配置文件路径")
+	fs.StringVar(&dir, "dir", "./desired", "期望状态yaml文件目录")
+	fs.BoolVar(&prune, "prune", false, "删除期望状态中未定义的源站组、加速域名及规则")
+	fs.BoolVar(&assumeYes, "yes", false, "跳过删除的交互确认，用于自动化执行")
+	fs.Parse(args)
+
+	state, err := entity.LoadDesiredState(dir)
+	if err != nil {
+		fmt.Printf("[Error] desired state load failed，err: %v\n", err)
+		return
+	}
+	c := entity.InitZoneCopyConfig(configPath)
+	z := usecase.NewZoneCopyManager(c)
+	if err := z.ResolveTargetZone(); err != nil {
+		fmt.Printf("[Error] zone resolve failed，err: %v\n", err)
+		return
+	}
+	if err := z.Apply(state, prune, confirmDelete, printAction); err != nil {
+		fmt.Printf("[Error] apply failed，err: %v\n", err)
+		return
+	}
+	fmt.Println("====> apply success!")
+}
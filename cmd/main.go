@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
 
 	"zonecopy/internal/domain/entity"
 	"zonecopy/internal/usecase"
@@ -15,38 +19,265 @@ func main() {
 		}
 	}()
 
-	var configPath, module string
-	flag.StringVar(&module, "module", "", "导入指定模块配置 \norigin: 源站组 \ndomain: 域名管理 \nzonesetting: 站点加速配置 \nrule: 规则引擎 \nall: 全部模块")
+	if len(os.Args) > 1 && os.Args[1] == "apply" {
+		runApply(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "watch" {
+		runWatch(os.Args[2:])
+		return
+	}
+
+	var configPath, module, prune string
+	var yes bool
+	flag.StringVar(&module, "module", "", "导入指定模块配置 \norigin: 源站组 \ndomain: 域名管理 \nzonesetting: 站点加速配置 \nrule: 规则引擎 \nalias: 别称域名 \ndns: DNS记录 \nipgroup: IP组 \nlog: 实时日志推送 \nfunction: 边缘函数 \nlb: 负载均衡 \nprotection: 源站防护 \ncontent: 内容标识符 \ncname: 导出目标站点的CNAME记录(不包含在all中) \nterraform: 导出模板站点的Terraform配置(不包含在all中) \nall: 全部模块")
+	flag.StringVar(&outputDir, "output", "./output", "导出文件保存目录")
 	flag.StringVar(&configPath, "config", "./config/cp.yaml", "配置文件路径")
+	flag.StringVar(&prune, "prune", "", "导入后删除目标站点中模板站点不存在的对象，删除前需确认，多个模块以逗号分隔 \norigin: 源站组 \ndomain: 域名管理 \nrule: 规则引擎")
+	flag.BoolVar(&yes, "yes", false, "跳过导入计划及删除的交互确认，用于自动化执行")
 	//解析参数
 	flag.Parse()
+	names := []string{module}
+	if module == "all" {
+		names = allModules
+	}
 	var modules []FuncModule
-	switch module {
-	case "origin":
-		modules = append(modules, moduleOrigin)
-	case "domain":
-		modules = append(modules, moduleDomain)
-	case "zonesetting":
-		modules = append(modules, moduleZoneSetting)
-	case "rule":
-		modules = append(modules, moduleRule)
-	case "all":
-		modules = []FuncModule{moduleOrigin, moduleDomain, moduleZoneSetting, moduleRule}
-	default:
-		panic(any("unsupported module!"))
+	for _, v := range names {
+		m, ok := lookupModule(v)
+		if !ok {
+			panic(any("unsupported module!"))
+		}
+		modules = append(modules, m)
 	}
 
 	c := entity.InitZoneCopyConfig(configPath)
 	z := usecase.NewZoneCopyManager(c)
+	if err := z.ResolveZones(); err != nil {
+		fmt.Printf("[Error] zone resolve failed，err: %v\n", err)
+		return
+	}
+	if c.CreateZone != nil {
+		fmt.Println("====> creating target zone, waiting for it to be active...")
+		if err := z.CreateTargetZone(printAction); err != nil {
+			fmt.Printf("[Error] target zone create failed，err: %v\n", err)
+			return
+		}
+		fmt.Printf("====> target zone %v is active, zone id: %v\n", c.TargetZone, c.TargetZoneId)
+	}
+	// 导出类模块只读取模板站点，无需预检
+	if !exportModules[module] {
+		if err := z.CheckVars(); err != nil {
+			fmt.Printf("[Error] vars check failed，err: %v\n", err)
+			return
+		}
+		printRisks(z.CheckCompatibility(names))
+	}
+	assumeYes = yes
+	if !confirmPlan(z, names, prune) {
+		return
+	}
 	for i, _ := range modules {
 		modules[i](z)
 	}
+	pruneModules(z, prune)
+}
+
+// pruneModules 按依赖关系逆序删除目标站点中模板站点不存在的对象。
+func pruneModules(z *usecase.ZoneCopyManager, prune string) {
+	if prune == "" {
+		return
+	}
+	selected := make(map[string]bool)
+	for _, v := range strings.Split(prune, ",") {
+		selected[strings.TrimSpace(v)] = true
+	}
+	steps := []struct {
+		module string
+		prune  func(confirm usecase.ConfirmFunc, notify func(msg string)) error
+	}{
+		{"rule", z.PruneRules},
+		{"domain", z.PruneDomains},
+		{"origin", z.PruneOriginGroups},
+	}
+	for _, v := range steps {
+		if !selected[v.module] {
+			continue
+		}
+		delete(selected, v.module)
+		if err := v.prune(confirmDelete, printAction); err != nil {
+			fmt.Printf("[Error] %v prune failed，err: %v\n", v.module, err)
+			return
+		}
+	}
+	for v := range selected {
+		fmt.Printf("[Warn] prune not supported for module %v\n", v)
+	}
+}
+
+// moduleTable 支持的全部模块，all 按表中顺序导入 inAll 为true的模块。
+var moduleTable = []struct {
+	name  string
+	run   FuncModule
+	inAll bool
+}{
+	{"origin", moduleOrigin, true},
+	{"lb", moduleLoadBalancer, true},
+	{"domain", moduleDomain, true},
+	{"zonesetting", moduleZoneSetting, true},
+	{"ipgroup", moduleIPGroup, true},
+	{"content", moduleContentIdentifier, true},
+	{"rule", moduleRule, true},
+	{"alias", moduleAliasDomain, true},
+	{"dns", moduleDnsRecord, true},
+	{"log", moduleRealtimeLog, true},
+	{"function", moduleFunction, true},
+	{"protection", moduleOriginProtection, true},
+	{"cname", moduleCnameExport, false},
+	{"terraform", moduleTerraformExport, false},
+}
+
+// allModules all 依次导入的模块。
+var allModules = func() []string {
+	var names []string
+	for _, v := range moduleTable {
+		if v.inAll {
+			names = append(names, v.name)
+		}
+	}
+	return names
+}()
+
+// lookupModule 按名称查找模块。
+func lookupModule(name string) (FuncModule, bool) {
+	for _, v := range moduleTable {
+		if v.name == name {
+			return v.run, true
+		}
+	}
+	return nil, false
+}
+
+// modulePlanners 支持生成导入计划的模块，其余模块在计划中仅列出模块名。
+var modulePlanners = map[string]func(z *usecase.ZoneCopyManager) ([]*usecase.PlanItem, error){
+	"origin":      (*usecase.ZoneCopyManager).PlanOriginGroups,
+	"domain":      (*usecase.ZoneCopyManager).PlanDomains,
+	"rule":        (*usecase.ZoneCopyManager).PlanRules,
+	"zonesetting": (*usecase.ZoneCopyManager).PlanZoneSetting,
+}
+
+// exportModules 只读取配置、不修改站点的模块，无需确认。
+var exportModules = map[string]bool{"cname": true, "terraform": true}
+
+// assumeYes 为true时跳过全部交互确认。
+var assumeYes bool
+
+// confirmPlan 按模块输出导入计划，存在覆盖目标站点配置的操作时需输入 yes 确认。
+func confirmPlan(z *usecase.ZoneCopyManager, modules []string, prune string) bool {
+	if len(modules) == 1 && exportModules[modules[0]] {
+		return true
+	}
+	fmt.Println("====> import plan:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	overwrite := false
+	for _, m := range modules {
+		planner, ok := modulePlanners[m]
+		if !ok {
+			fmt.Fprintf(w, "%v\t*\timport, existing objects are skipped\n", m)
+			continue
+		}
+		items, err := planner(z)
+		if err != nil {
+			w.Flush()
+			fmt.Printf("[Error] %v plan failed，err: %v\n", m, err)
+			return false
+		}
+		for _, v := range items {
+			fmt.Fprintf(w, "%v\t%v\t%v\n", m, v.Object, v.Action)
+			overwrite = overwrite || v.Action == usecase.PlanOverwrite
+		}
+	}
+	if prune != "" {
+		fmt.Fprintf(w, "prune\t%v\tdelete, confirmed separately\n", prune)
+	}
+	w.Flush()
+	if !overwrite || assumeYes {
+		return true
+	}
+	fmt.Print("existing target configuration will be overwritten, type yes to confirm: ")
+	if !readYes() {
+		fmt.Println("====> import canceled")
+		return false
+	}
+	return true
+}
+
+// confirmDelete 列出待删除的对象，输入 yes 后才执行删除。
+func confirmDelete(kind string, names []string) bool {
+	fmt.Printf("====> the following %v(s) will be deleted:\n", kind)
+	for _, v := range names {
+		fmt.Println("  -", v)
+	}
+	if assumeYes {
+		return true
+	}
+	fmt.Print("type yes to confirm: ")
+	if !readYes() {
+		fmt.Printf("====> %v deletion skipped\n", kind)
+		return false
+	}
+	return true
+}
+
+func readYes() bool {
+	answer, _ := stdin.ReadString('\n')
+	return strings.TrimSpace(answer) == "yes"
+}
+
+func printAction(msg string) {
+	fmt.Println("[Action]", msg)
 }
 
 type FuncModule func(z *usecase.ZoneCopyManager)
 
+// outputDir 导出类模块的文件保存目录。
+var outputDir string
+
+// stdin 交互确认的输入，多次确认共用同一缓冲。
+var stdin = bufio.NewReader(os.Stdin)
+
+// printRisks 输出导入前预检发现的风险项。
+func printRisks(risks []string) {
+	for _, v := range risks {
+		fmt.Printf("[Warn] %v\n", v)
+	}
+}
+
+// printDomainVerifications 以表格形式输出加速域名需要添加的CNAME及归属权校验记录。
+func printDomainVerifications(list []*usecase.DomainVerification) {
+	if len(list) == 0 {
+		return
+	}
+	fmt.Println("====> please add the following dns records:")
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DOMAIN\tSTATUS\tCNAME\tVERIFY RECORD")
+	for _, v := range list {
+		verify := "-"
+		if v.VerifyName != "" {
+			verify = fmt.Sprintf("%v %v %v", v.VerifyName, v.VerifyType, v.VerifyValue)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", v.DomainName, v.DomainStatus, v.Cname, verify)
+	}
+	w.Flush()
+}
+
 var (
 	moduleOrigin FuncModule = func(z *usecase.ZoneCopyManager) {
+		risks, err := z.CheckOriginGroups()
+		if err != nil {
+			fmt.Printf("[Error] origin group check failed，err: %v\n", err)
+			return
+		}
+		printRisks(risks)
 		if err := z.ImportOrigin(); err != nil {
 			fmt.Printf("[Error] origin group import failed，err: %v\n", err)
 		} else {
@@ -54,18 +285,36 @@ var (
 		}
 	}
 	moduleDomain FuncModule = func(z *usecase.ZoneCopyManager) {
+		risks, err := z.CheckDomainOrigins()
+		if err != nil {
+			fmt.Printf("[Error] domain origin check failed，err: %v\n", err)
+			return
+		}
+		printRisks(risks)
 		if err := z.ImportDomains(); err != nil {
 			fmt.Printf("[Error] domain import failed，err: %v\n", err)
-		} else {
-			fmt.Println("====> domain import success!")
+			return
+		}
+		fmt.Println("====> domain import success!")
+		pending, err := z.VerifyDomains(printDomainVerifications)
+		if err != nil {
+			fmt.Printf("[Error] domain verify failed，err: %v\n", err)
+			return
+		}
+		for _, v := range pending {
+			fmt.Printf("[Warn] domain %v is not online, status: %v\n", v.DomainName, v.DomainStatus)
 		}
 	}
 	moduleZoneSetting FuncModule = func(z *usecase.ZoneCopyManager) {
-		if err := z.ImportZoneSetting(); err != nil {
+		skipped, err := z.ImportZoneSetting()
+		if err != nil {
 			fmt.Printf("[Error] zone setting import failed，err: %v\n", err)
 		} else {
 			fmt.Println("====> zone setting import success!")
 		}
+		if len(skipped) > 0 {
+			fmt.Printf("[Warn] zone setting not accepted by target zone, please copy manually: %v\n", skipped)
+		}
 	}
 	moduleRule FuncModule = func(z *usecase.ZoneCopyManager) {
 		if err := z.ImportRuleEngineRules(); err != nil {
@@ -74,4 +323,98 @@ var (
 			fmt.Println("====> rule engine import success!")
 		}
 	}
+	moduleAliasDomain FuncModule = func(z *usecase.ZoneCopyManager) {
+		skipped, err := z.ImportAliasDomains()
+		if err != nil {
+			fmt.Printf("[Error] alias domain import failed，err: %v\n", err)
+		} else {
+			fmt.Println("====> alias domain import success!")
+		}
+		for _, v := range skipped {
+			fmt.Printf("[Warn] %v, please configure it manually\n", v)
+		}
+	}
+	moduleDnsRecord FuncModule = func(z *usecase.ZoneCopyManager) {
+		skipped, err := z.ImportDnsRecords()
+		switch {
+		case err != nil:
+			fmt.Printf("[Error] dns record import failed，err: %v\n", err)
+		case len(skipped) > 0:
+			for _, v := range skipped {
+				fmt.Printf("[Warn] %v\n", v)
+			}
+		default:
+			fmt.Println("====> dns record import success!")
+		}
+	}
+	moduleIPGroup FuncModule = func(z *usecase.ZoneCopyManager) {
+		if err := z.ImportIPGroups(); err != nil {
+			fmt.Printf("[Error] ip group import failed，err: %v\n", err)
+		} else {
+			fmt.Println("====> ip group import success!")
+		}
+	}
+	moduleRealtimeLog FuncModule = func(z *usecase.ZoneCopyManager) {
+		skipped, err := z.ImportRealtimeLogDeliveryTasks()
+		if err != nil {
+			fmt.Printf("[Error] realtime log delivery task import failed，err: %v\n", err)
+		} else {
+			fmt.Println("====> realtime log delivery task import success!")
+		}
+		for _, v := range skipped {
+			fmt.Printf("[Warn] %v, please configure it manually\n", v)
+		}
+	}
+	moduleFunction FuncModule = func(z *usecase.ZoneCopyManager) {
+		if err := z.ImportFunctions(); err != nil {
+			fmt.Printf("[Error] function import failed，err: %v\n", err)
+		} else {
+			fmt.Println("====> function import success!")
+		}
+	}
+	moduleLoadBalancer FuncModule = func(z *usecase.ZoneCopyManager) {
+		if err := z.ImportLoadBalancers(); err != nil {
+			fmt.Printf("[Error] load balancer import failed，err: %v\n", err)
+		} else {
+			fmt.Println("====> load balancer import success!")
+		}
+	}
+	moduleOriginProtection FuncModule = func(z *usecase.ZoneCopyManager) {
+		ips, err := z.ImportOriginProtection()
+		if err != nil {
+			fmt.Printf("[Error] origin protection import failed，err: %v\n", err)
+			return
+		}
+		fmt.Println("====> origin protection import success!")
+		if ips == nil {
+			return
+		}
+		fmt.Println("====> please allow the following ip ranges at origin:")
+		for _, v := range append(ips.IPv4, ips.IPv6...) {
+			fmt.Println(*v)
+		}
+	}
+	moduleContentIdentifier FuncModule = func(z *usecase.ZoneCopyManager) {
+		if err := z.ImportContentIdentifiers(); err != nil {
+			fmt.Printf("[Error] content identifier import failed，err: %v\n", err)
+		} else {
+			fmt.Println("====> content identifier import success!")
+		}
+	}
+	moduleCnameExport FuncModule = func(z *usecase.ZoneCopyManager) {
+		files, err := z.ExportCnameRecords(outputDir)
+		if err != nil {
+			fmt.Printf("[Error] cname record export failed，err: %v\n", err)
+			return
+		}
+		fmt.Printf("====> cname record export success! files: %v\n", files)
+	}
+	moduleTerraformExport FuncModule = func(z *usecase.ZoneCopyManager) {
+		files, err := z.ExportTerraform(outputDir)
+		if err != nil {
+			fmt.Printf("[Error] terraform export failed，err: %v\n", err)
+			return
+		}
+		fmt.Printf("====> terraform export success! files: %v\n", files)
+	}
 )
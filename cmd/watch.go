@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"zonecopy/internal/domain/entity"
+	"zonecopy/internal/usecase"
+)
+
+// runWatch watch 命令，定期生成模板站点快照，将与上次快照相比的变更同步到各目标站点。
+func runWatch(args []string) {
+	var configPaths, statePath string
+	var interval int
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	fs.StringVar(&configPaths, "config", "./config/cp.yaml", "配置文件路径，多个目标站点的配置文件以逗号分隔，模板站点需相同")
+	fs.IntVar(&interval, "interval", 300, "模板站点快照间隔(秒)")
+	fs.StringVar(&statePath, "state", "./watch.state.json", "各目标站点上次同步快照的保存路径")
+	fs.Parse(args)
+	if interval <= 0 {
+		fmt.Printf("[Error] interval must be greater than 0, got %v\n", interval)
+		return
+	}
+
+	var configs []*entity.ZoneCopyConfig
+	for _, path := range strings.Split(configPaths, ",") {
+		c := entity.InitZoneCopyConfig(strings.TrimSpace(path))
+		if err := usecase.NewZoneCopyManager(c).ResolveZones(); err != nil {
+			fmt.Printf("[Error] %v zone resolve failed，err: %v\n", path, err)
+			return
+		}
+		if len(configs) > 0 && c.TemplateZoneId != configs[0].TemplateZoneId {
+			fmt.Printf("[Error] %v template zone %v differs from %v\n", path, c.TemplateZoneId, configs[0].TemplateZoneId)
+			return
+		}
+		configs = append(configs, c)
+	}
+	for {
+		watchOnce(configs, statePath)
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
+
+// watchOnce 生成一次快照，按各目标站点上次同步成功的快照计算变更并同步，同步失败的目标站点在下一轮重试。
+func watchOnce(configs []*entity.ZoneCopyConfig, statePath string) {
+	snap, err := usecase.NewZoneCopyManager(configs[0]).Snapshot()
+	if err != nil {
+		fmt.Printf("[Error] template zone snapshot failed，err: %v\n", err)
+		return
+	}
+	state, err := usecase.LoadWatchState(statePath)
+	if err != nil {
+		fmt.Printf("[Error] watch state load failed，err: %v\n", err)
+		return
+	}
+	updated := false
+	for _, c := range configs {
+		old := state.Targets[c.TargetZoneId]
+		if old == nil || old.ZoneId != snap.ZoneId {
+			state.Targets[c.TargetZoneId] = snap
+			updated = true
+			fmt.Printf("====> %v baseline snapshot saved for %v\n", time.Now().Format(time.RFC3339), c.TargetZone)
+			continue
+		}
+		changes := usecase.DiffSnapshot(old, snap)
+		if changes.Empty() {
+			continue
+		}
+		fmt.Printf("====> %v syncing changes to %v\n", time.Now().Format(time.RFC3339), c.TargetZone)
+		// 每轮使用新的实例，避免沿用上一轮缓存的源站组等Id映射
+		z := usecase.NewZoneCopyManager(c)
+		if err = z.CheckVars(); err != nil {
+			fmt.Printf("[Error] %v vars check failed，err: %v\n", c.TargetZone, err)
+			continue
+		}
+		if err = z.SyncChanges(changes, printAction); err != nil {
+			fmt.Printf("[Error] %v sync failed，err: %v\n", c.TargetZone, err)
+			continue
+		}
+		state.Targets[c.TargetZoneId] = snap
+		updated = true
+	}
+	if !updated {
+		return
+	}
+	if err = state.Save(statePath); err != nil {
+		fmt.Printf("[Error] watch state save failed，err: %v\n", err)
+	}
+}
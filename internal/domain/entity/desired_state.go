@@ -0,0 +1,143 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/pkg/utils"
+)
+
+// DesiredState 目标站点的期望状态，由目录下的多个yaml文件合并而成，对象字段名与API参数名一致。
+// 源站组通过名称引用：加速域名回源源站组及规则引擎 Origin 操作中的 OriginGroupId 填写源站组名称。
+type DesiredState struct {
+	OriginGroups []*teo.OriginGroup
+	Domains      []*DesiredDomain
+	Rules        []*teo.RuleItem
+	ZoneSetting  *teo.ZoneSetting
+}
+
+// DesiredDomain 加速域名期望状态。
+type DesiredDomain struct {
+	DomainName *string         `json:"DomainName,omitempty"`
+	OriginInfo *teo.OriginInfo `json:"OriginInfo,omitempty"`
+}
+
+// desiredStateFile 期望状态文件，各对象先按yaml解析，再按API参数名转换为SDK结构。
+type desiredStateFile struct {
+	OriginGroups []interface{} `yaml:"origin_groups"`
+	Domains      []interface{} `yaml:"domains"`
+	Rules        []interface{} `yaml:"rules"`
+	ZoneSetting  interface{}   `yaml:"zone_setting"`
+}
+
+// LoadDesiredState 读取目录下全部 .yaml 文件并合并为期望状态，同名对象重复定义时报错。
+func LoadDesiredState(dir string) (*DesiredState, error) {
+	files, err := utils.GetFileList(dir, ".yaml")
+	if err != nil {
+		return nil, err
+	}
+	s := &DesiredState{}
+	names := make(map[string]string)
+	for _, path := range files {
+		f := &desiredStateFile{}
+		if err = utils.PraseConfig(path, f); err != nil {
+			return nil, fmt.Errorf("%v: %v", path, err)
+		}
+		for i, v := range f.OriginGroups {
+			g := &teo.OriginGroup{}
+			if err = convertYamlValue(v, g); err != nil {
+				return nil, fmt.Errorf("%v: origin_groups[%d]: %v", path, i, err)
+			}
+			if err = checkDesiredName(names, "origin group", g.OriginGroupName, path); err != nil {
+				return nil, err
+			}
+			s.OriginGroups = append(s.OriginGroups, g)
+		}
+		for i, v := range f.Domains {
+			d := &DesiredDomain{}
+			if err = convertYamlValue(v, d); err != nil {
+				return nil, fmt.Errorf("%v: domains[%d]: %v", path, i, err)
+			}
+			if d.OriginInfo == nil {
+				return nil, fmt.Errorf("%v: domains[%d]: empty OriginInfo", path, i)
+			}
+			if err = checkDesiredName(names, "domain", d.DomainName, path); err != nil {
+				return nil, err
+			}
+			s.Domains = append(s.Domains, d)
+		}
+		for i, v := range f.Rules {
+			r := &teo.RuleItem{}
+			if err = convertYamlValue(v, r); err != nil {
+				return nil, fmt.Errorf("%v: rules[%d]: %v", path, i, err)
+			}
+			if err = checkDesiredName(names, "rule", r.RuleName, path); err != nil {
+				return nil, err
+			}
+			// 与拷贝导入一致，未填写状态的规则默认启用
+			if r.Status == nil || *r.Status == "" {
+				r.Status = common.StringPtr("enable")
+			}
+			s.Rules = append(s.Rules, r)
+		}
+		if f.ZoneSetting != nil {
+			if s.ZoneSetting != nil {
+				return nil, fmt.Errorf("%v: zone_setting is already defined", path)
+			}
+			s.ZoneSetting = &teo.ZoneSetting{}
+			if err = convertYamlValue(f.ZoneSetting, s.ZoneSetting); err != nil {
+				return nil, fmt.Errorf("%v: zone_setting: %v", path, err)
+			}
+		}
+	}
+	return s, nil
+}
+
+func checkDesiredName(names map[string]string, kind string, name *string, path string) error {
+	if name == nil || *name == "" {
+		return fmt.Errorf("%v: %v without name", path, kind)
+	}
+	key := kind + "/" + *name
+	if old, ok := names[key]; ok {
+		return fmt.Errorf("%v: %v %v is already defined in %v", path, kind, *name, old)
+	}
+	names[key] = path
+	return nil
+}
+
+// convertYamlValue 将yaml解析结果按json字段名转换为目标结构。
+// yaml 会将未加引号的 on/off/yes/no 解析为布尔值，字符串字段取到布尔值或数字时提示加引号。
+func convertYamlValue(src, dst interface{}) error {
+	body, err := json.Marshal(jsonValue(src))
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(body, dst)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Type.Kind() == reflect.String {
+		return fmt.Errorf("%v: expect a string but got a %v, quote the value, e.g. Switch: \"on\"", typeErr.Field, typeErr.Value)
+	}
+	return err
+}
+
+// jsonValue 将yaml解析出的 map[interface{}]interface{} 转换为json可序列化的 map[string]interface{}。
+func jsonValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[fmt.Sprint(k)] = jsonValue(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range t {
+			t[i] = jsonValue(val)
+		}
+		return t
+	}
+	return v
+}
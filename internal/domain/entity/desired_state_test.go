@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestConvertYamlValue(t *testing.T) {
+	cases := []struct {
+		name    string
+		doc     string
+		wantErr string
+		check   func(s *teo.ZoneSetting) bool
+	}{
+		{
+			name:  "quoted switch",
+			doc:   "Quic:\n  Switch: \"on\"\n",
+			check: func(s *teo.ZoneSetting) bool { return s.Quic != nil && *s.Quic.Switch == "on" },
+		},
+		{
+			name:    "unquoted switch",
+			doc:     "Quic:\n  Switch: on\n",
+			wantErr: "Quic.Switch: expect a string but got a bool",
+		},
+		{
+			name:  "nested numbers",
+			doc:   "PostMaxSize:\n  Switch: \"on\"\n  MaxSize: 524288000\n",
+			check: func(s *teo.ZoneSetting) bool { return *s.PostMaxSize.MaxSize == 524288000 },
+		},
+		{
+			name:  "string list",
+			doc:   "Compression:\n  Algorithms: [brotli, gzip]\n",
+			check: func(s *teo.ZoneSetting) bool { return len(s.Compression.Algorithms) == 2 },
+		},
+	}
+	for _, c := range cases {
+		var src interface{}
+		if err := yaml.Unmarshal([]byte(c.doc), &src); err != nil {
+			t.Fatalf("%v: yaml.Unmarshal err: %v", c.name, err)
+		}
+		dst := &teo.ZoneSetting{}
+		err := convertYamlValue(src, dst)
+		if c.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+				t.Errorf("%v: err = %v, want %q", c.name, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected err: %v", c.name, err)
+			continue
+		}
+		if !c.check(dst) {
+			t.Errorf("%v: unexpected result %+v", c.name, dst)
+		}
+	}
+}
+
+func TestLoadDesiredStateRuleStatus(t *testing.T) {
+	dir := t.TempDir()
+	doc := "rules:\n  - RuleName: a\n  - RuleName: b\n    Status: disable\n"
+	if err := os.WriteFile(filepath.Join(dir, "rules.yaml"), []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+	s, err := LoadDesiredState(dir)
+	if err != nil {
+		t.Fatalf("LoadDesiredState err: %v", err)
+	}
+	want := map[string]string{"a": "enable", "b": "disable"}
+	for _, r := range s.Rules {
+		if *r.Status != want[*r.RuleName] {
+			t.Errorf("rule %v status = %v, want %v", *r.RuleName, *r.Status, want[*r.RuleName])
+		}
+	}
+}
@@ -2,7 +2,6 @@ package entity
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"os"
 
@@ -18,14 +17,74 @@ type AccountBaseInfo struct {
 	Region    string `yaml:"region" validate:"required"`
 }
 
-// ZoneCopyConfig 初始化配置
+// ZoneCopyConfig 初始化配置，站点名称和站点Id填写其一即可，缺失的一项在运行时通过接口查询补全。
 type ZoneCopyConfig struct {
-	LogPath        string           `yaml:"log_path" validate:"required"`
-	Account        *AccountBaseInfo `yaml:"account" validate:"required"`
-	TemplateZone   string           `yaml:"template_zone" validate:"required"`
-	TemplateZoneId string           `yaml:"template_zone_id" validate:"required"`
-	TargetZone     string           `yaml:"target_zone" validate:"required"`
-	TargetZoneId   string           `yaml:"target_zone_id" validate:"required"`
+	LogPath        string              `yaml:"log_path" validate:"required"`
+	Account        *AccountBaseInfo    `yaml:"account" validate:"required"`
+	TemplateZone   string              `yaml:"template_zone" validate:"required_without=TemplateZoneId"`
+	TemplateZoneId string              `yaml:"template_zone_id" validate:"required_without=TemplateZone"`
+	TargetZone     string              `yaml:"target_zone" validate:"required_without=TargetZoneId,required_with=CreateZone"`
+	TargetZoneId   string              `yaml:"target_zone_id" validate:"required_without=TargetZone,excluded_with=CreateZone"`
+	CreateZone     *CreateZoneConfig   `yaml:"create_zone"`
+	DomainVerify   *DomainVerifyConfig `yaml:"domain_verify"`
+	RewriteOrigin  bool                `yaml:"rewrite_origin"` // 源站记录及回源HOST中的模板站点域名是否替换为目标站点域名
+	Overrides      *Overrides          `yaml:"overrides"`
+	Vars           map[string]string   `yaml:"vars"`                                    // 模板站点配置值中 ${var} 占位符的取值
+	PruneProtected []string            `yaml:"prune_protected"`                         // 删除时跳过的源站组、域名或规则名称，支持通配符
+	DomainQuota    int                 `yaml:"domain_quota" validate:"omitempty,min=1"` // 目标站点套餐的加速域名配额，接口无法查询，配置后导入前检查
+}
+
+// CreateZoneConfig 目标站点创建配置，配置后在导入前按模板站点的接入方式创建目标站点并等待生效。
+type CreateZoneConfig struct {
+	PlanId       string `yaml:"plan_id" validate:"required"`              // 目标站点绑定的套餐Id，多数套餐仅支持绑定一个站点，需单独指定
+	PollInterval int    `yaml:"poll_interval" validate:"omitempty,min=1"` // 站点状态轮询间隔(秒)，默认30
+	Timeout      int    `yaml:"timeout" validate:"omitempty,min=1"`       // 等待站点生效的超时时间(分钟)，默认60
+}
+
+// DomainVerifyConfig 域名导入后的归属权校验配置，配置后输出校验报告并等待CNAME接入站点的加速域名生效。
+type DomainVerifyConfig struct {
+	OutputDir    string `yaml:"output_dir" validate:"required"`           // 校验报告(JSON及zone文件)输出目录
+	PollInterval int    `yaml:"poll_interval" validate:"omitempty,min=1"` // 域名状态轮询间隔(秒)，默认30
+	Timeout      int    `yaml:"timeout" validate:"omitempty,min=1"`       // 等待域名生效的超时时间(分钟)，默认60
+}
+
+// Overrides 目标站点的源站配置覆盖，导入时替换模板站点中的对应配置。
+type Overrides struct {
+	OriginGroups map[string]*OriginGroupOverride `yaml:"origin_groups" validate:"dive"` // 源站组名称 -> 覆盖配置
+	Domains      map[string]*DomainOverride      `yaml:"domains" validate:"dive"`       // 目标站点域名 -> 覆盖配置
+}
+
+// OriginGroupOverride 源站组覆盖配置。
+type OriginGroupOverride struct {
+	HostHeader string                  `yaml:"host_header"`
+	Records    []*OriginRecordOverride `yaml:"records" validate:"dive"`
+}
+
+// OriginRecordOverride 源站记录覆盖配置，未填写的字段保持模板站点配置。
+type OriginRecordOverride struct {
+	Match             string              `yaml:"match" validate:"required"` // 模板站点中的源站记录值
+	Record            string              `yaml:"record"`
+	Port              *uint64             `yaml:"port"`
+	Weight            *uint64             `yaml:"weight"`
+	Private           *bool               `yaml:"private"`
+	PrivateParameters []*PrivateParameter `yaml:"private_parameters" validate:"dive"`
+}
+
+// DomainOverride 加速域名覆盖配置，未填写的字段保持模板站点配置。
+type DomainOverride struct {
+	Origin            string              `yaml:"origin"`
+	BackupOrigin      string              `yaml:"backup_origin"`
+	HostHeader        string              `yaml:"host_header"`
+	HttpOriginPort    *uint64             `yaml:"http_origin_port"`
+	HttpsOriginPort   *uint64             `yaml:"https_origin_port"`
+	PrivateAccess     string              `yaml:"private_access" validate:"omitempty,oneof=on off"`
+	PrivateParameters []*PrivateParameter `yaml:"private_parameters" validate:"dive"`
+}
+
+// PrivateParameter 私有鉴权参数。
+type PrivateParameter struct {
+	Name  string `yaml:"name" validate:"required"`
+	Value string `yaml:"value" validate:"required"`
 }
 
 func InitZoneCopyConfig(configPath string) *ZoneCopyConfig {
@@ -53,6 +112,6 @@ func InitZoneCopyConfig(configPath string) *ZoneCopyConfig {
 func ZoneCopyConfigExport(path string, z *ZoneCopyConfig) {
 	err := utils.GenerateConfig(path, z)
 	if err != nil {
-		fmt.Errorf("utils.GenerateConfig failed, err: %v", err)
+		log.Printf("utils.GenerateConfig failed, err: %v\n", err)
 	}
 }
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestCreateZoneConfigValidate(t *testing.T) {
+	base := func() *ZoneCopyConfig {
+		return &ZoneCopyConfig{
+			LogPath:        "./cp.log",
+			Account:        &AccountBaseInfo{SecretId: "id", SecretKey: "key", EndPoint: "teo.tencentcloudapi.com", Region: "ap-guangzhou"},
+			TemplateZoneId: "zone-template",
+			TargetZone:     "example.com",
+			CreateZone:     &CreateZoneConfig{PlanId: "edgeone-xxx"},
+		}
+	}
+	cases := []struct {
+		name    string
+		modify  func(c *ZoneCopyConfig)
+		wantErr bool
+	}{
+		{"valid", func(c *ZoneCopyConfig) {}, false},
+		{"plan id missing", func(c *ZoneCopyConfig) { c.CreateZone.PlanId = "" }, true},
+		{"target zone id configured", func(c *ZoneCopyConfig) { c.TargetZoneId = "zone-target" }, true},
+		{"target zone id without create zone", func(c *ZoneCopyConfig) {
+			c.CreateZone = nil
+			c.TargetZoneId = "zone-target"
+		}, false},
+	}
+	for _, c := range cases {
+		cfg := base()
+		c.modify(cfg)
+		err := validator.New().Struct(cfg)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%v: Struct() err = %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+// AliasDomainManager 别称域名导入。
+type AliasDomainManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewAliasDomainManager(a *entity.AccountBaseInfo) *AliasDomainManager {
+	return &AliasDomainManager{
+		Account: a,
+	}
+}
+
+// AliasDomain 别称域名，当前SDK版本的AliasDomain中未包含证书配置字段。
+type AliasDomain struct {
+	*teo.AliasDomain
+	CertType *string   `json:"CertType,omitempty"`
+	CertId   []*string `json:"CertId,omitempty"`
+}
+
+type describeAliasDomainsRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+	Offset *int64  `json:"Offset,omitempty"`
+	Limit  *int64  `json:"Limit,omitempty"`
+}
+
+type describeAliasDomainsResponse struct {
+	TotalCount   *int64         `json:"TotalCount,omitempty"`
+	AliasDomains []*AliasDomain `json:"AliasDomains,omitempty"`
+}
+
+// DescribeAliasDomainList 分页查询站点下的全部别称域名及其证书配置。
+func (a *AliasDomainManager) DescribeAliasDomainList(zoneId string) ([]*AliasDomain, error) {
+	var list []*AliasDomain
+	limit := int64(200)
+	for offset := int64(0); ; offset += limit {
+		request := &describeAliasDomainsRequest{
+			ZoneId: common.StringPtr(zoneId),
+			Offset: common.Int64Ptr(offset),
+			Limit:  common.Int64Ptr(limit),
+		}
+		response := &describeAliasDomainsResponse{}
+		if err := sendTeoRequest(a.Account, "DescribeAliasDomains", request, response); err != nil {
+			return nil, err
+		}
+		list = append(list, response.AliasDomains...)
+		if response.TotalCount == nil || offset+limit >= *response.TotalCount {
+			break
+		}
+	}
+	return list, nil
+}
+
+func (a *AliasDomainManager) IsAliasDomainExist(zoneId, aliasName string) (bool, error) {
+	credential := common.NewCredential(
+		a.Account.SecretId,
+		a.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = a.Account.EndPoint
+	client, _ := teo.NewClient(credential, a.Account.Region, cpf)
+
+	request := teo.NewDescribeAliasDomainsRequest()
+	request.ZoneId = common.StringPtr(zoneId)
+	request.Filters = []*teo.AdvancedFilter{
+		&teo.AdvancedFilter{
+			Name:   common.StringPtr("alias-name"),
+			Values: common.StringPtrs([]string{aliasName}),
+		},
+	}
+	log.Printf("[API] IsAliasDomainExist Request: %#v", request.ToJsonString())
+
+	response, err := client.DescribeAliasDomains(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return false, fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return false, zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] IsAliasDomainExist response: %#v", response.ToJsonString())
+	if *response.Response.TotalCount > 0 {
+		return true, nil
+	}
+	return false, nil
+}
+
+func (a *AliasDomainManager) CreateAliasDomain(request *teo.CreateAliasDomainRequest) error {
+	ok, err := a.IsAliasDomainExist(*request.ZoneId, *request.AliasName)
+	if err != nil {
+		return zerr.Wrap(err, "a.IsAliasDomainExist failed")
+	}
+	if ok {
+		log.Printf("alias domain: %v is already exist \n", *request.AliasName)
+		return nil
+	}
+
+	credential := common.NewCredential(
+		a.Account.SecretId,
+		a.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = a.Account.EndPoint
+	client, _ := teo.NewClient(credential, a.Account.Region, cpf)
+	log.Printf("[API] CreateAliasDomain Request: %#v", request.ToJsonString())
+
+	response, err := client.CreateAliasDomain(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] CreateAliasDomain response: %#v", response.ToJsonString())
+	return nil
+}
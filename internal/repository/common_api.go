@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	tchttp "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/http"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+	"zonecopy/internal/domain/entity"
+)
+
+const (
+	teoService = "teo"
+	teoVersion = "2022-09-01"
+)
+
+// sendTeoRequest 调用当前SDK版本未封装的TEO接口，返回结果解析到response中。
+func sendTeoRequest(a *entity.AccountBaseInfo, action string, params interface{}, response interface{}) error {
+	credential := common.NewCredential(
+		a.SecretId,
+		a.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = a.EndPoint
+	client := common.NewCommonClient(credential, a.Region, cpf)
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return zerr.Wrap(err, "json.Marshal failed")
+	}
+	request := tchttp.NewCommonRequest(teoService, teoVersion, action)
+	if err = request.SetActionParameters(body); err != nil {
+		return zerr.Wrap(err, "request.SetActionParameters failed")
+	}
+	log.Printf("[API] %v Request: %#v", action, string(body))
+
+	resp := tchttp.NewCommonResponse()
+	err = client.Send(request, resp)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] %v response: %#v", action, string(resp.GetBody()))
+
+	wrapper := struct {
+		Response json.RawMessage `json:"Response"`
+	}{}
+	if err = json.Unmarshal(resp.GetBody(), &wrapper); err != nil {
+		return zerr.Wrap(err, "json.Unmarshal failed")
+	}
+	if response == nil {
+		return nil
+	}
+	if err = json.Unmarshal(wrapper.Response, response); err != nil {
+		return zerr.Wrap(err, "json.Unmarshal failed")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/domain/entity"
+)
+
+// ContentIdentifier 内容标识符，当前SDK版本未封装内容标识符相关接口。
+type ContentIdentifier struct {
+	ContentId   *string         `json:"ContentId,omitempty"`
+	Description *string         `json:"Description,omitempty"`
+	PlanId      *string         `json:"PlanId,omitempty"`
+	Tags        json.RawMessage `json:"Tags,omitempty"`
+	Status      *string         `json:"Status,omitempty"`
+}
+
+type describeContentIdentifiersRequest struct {
+	Offset *int64 `json:"Offset,omitempty"`
+	Limit  *int64 `json:"Limit,omitempty"`
+}
+
+type describeContentIdentifiersResponse struct {
+	TotalCount         *int64               `json:"TotalCount,omitempty"`
+	ContentIdentifiers []*ContentIdentifier `json:"ContentIdentifiers,omitempty"`
+}
+
+type createContentIdentifierResponse struct {
+	ContentId *string `json:"ContentId,omitempty"`
+}
+
+// ContentIdentifierManager 内容标识符导入，内容标识符归属于套餐而非站点。
+type ContentIdentifierManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewContentIdentifierManager(a *entity.AccountBaseInfo) *ContentIdentifierManager {
+	return &ContentIdentifierManager{
+		Account: a,
+	}
+}
+
+// DescribeContentIdentifierList 查询指定套餐下的内容标识符。
+func (c *ContentIdentifierManager) DescribeContentIdentifierList(planId string) ([]*ContentIdentifier, error) {
+	var list []*ContentIdentifier
+	limit := int64(100)
+	for offset := int64(0); ; offset += limit {
+		request := &describeContentIdentifiersRequest{
+			Offset: common.Int64Ptr(offset),
+			Limit:  common.Int64Ptr(limit),
+		}
+		response := &describeContentIdentifiersResponse{}
+		if err := sendTeoRequest(c.Account, "DescribeContentIdentifiers", request, response); err != nil {
+			return nil, err
+		}
+		for _, v := range response.ContentIdentifiers {
+			if v.PlanId != nil && *v.PlanId == planId {
+				list = append(list, v)
+			}
+		}
+		if response.TotalCount == nil || offset+limit >= *response.TotalCount {
+			break
+		}
+	}
+	return list, nil
+}
+
+func (c *ContentIdentifierManager) CreateContentIdentifier(planId string, content *ContentIdentifier) (string, error) {
+	list, err := c.DescribeContentIdentifierList(planId)
+	if err != nil {
+		return "", zerr.Wrap(err, "c.DescribeContentIdentifierList failed")
+	}
+	for _, v := range list {
+		if stringValue(v.Description) == stringValue(content.Description) {
+			return *v.ContentId, nil
+		}
+	}
+	request := &ContentIdentifier{
+		Description: content.Description,
+		PlanId:      common.StringPtr(planId),
+		Tags:        content.Tags,
+	}
+	response := &createContentIdentifierResponse{}
+	if err = sendTeoRequest(c.Account, "CreateContentIdentifier", request, response); err != nil {
+		return "", err
+	}
+	return *response.ContentId, nil
+}
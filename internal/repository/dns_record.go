@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+// DnsRecord DNS记录，当前SDK版本未封装DNS记录相关接口。
+type DnsRecord struct {
+	ZoneId   *string `json:"ZoneId,omitempty"`
+	RecordId *string `json:"RecordId,omitempty"`
+	Name     *string `json:"Name,omitempty"`
+	Type     *string `json:"Type,omitempty"`
+	Location *string `json:"Location,omitempty"`
+	Content  *string `json:"Content,omitempty"`
+	TTL      *int64  `json:"TTL,omitempty"`
+	Weight   *int64  `json:"Weight,omitempty"`
+	Priority *int64  `json:"Priority,omitempty"`
+	Status   *string `json:"Status,omitempty"`
+}
+
+type describeDnsRecordsRequest struct {
+	ZoneId  *string               `json:"ZoneId,omitempty"`
+	Offset  *int64                `json:"Offset,omitempty"`
+	Limit   *int64                `json:"Limit,omitempty"`
+	Filters []*teo.AdvancedFilter `json:"Filters,omitempty"`
+}
+
+type describeDnsRecordsResponse struct {
+	TotalCount *int64       `json:"TotalCount,omitempty"`
+	DnsRecords []*DnsRecord `json:"DnsRecords,omitempty"`
+}
+
+// DnsRecordManager DNS记录导入。
+type DnsRecordManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewDnsRecordManager(a *entity.AccountBaseInfo) *DnsRecordManager {
+	return &DnsRecordManager{
+		Account: a,
+	}
+}
+
+func (d *DnsRecordManager) DescribeDnsRecordList(zoneId string) ([]*DnsRecord, error) {
+	return d.describeDnsRecords(zoneId, nil)
+}
+
+// describeDnsRecords 按过滤条件分页查询站点下的全部DNS记录。
+func (d *DnsRecordManager) describeDnsRecords(zoneId string, filters []*teo.AdvancedFilter) ([]*DnsRecord, error) {
+	var list []*DnsRecord
+	limit := int64(1000)
+	for offset := int64(0); ; offset += limit {
+		request := &describeDnsRecordsRequest{
+			ZoneId:  common.StringPtr(zoneId),
+			Offset:  common.Int64Ptr(offset),
+			Limit:   common.Int64Ptr(limit),
+			Filters: filters,
+		}
+		response := &describeDnsRecordsResponse{}
+		if err := sendTeoRequest(d.Account, "DescribeDnsRecords", request, response); err != nil {
+			return nil, err
+		}
+		list = append(list, response.DnsRecords...)
+		if response.TotalCount == nil || offset+limit >= *response.TotalCount {
+			break
+		}
+	}
+	return list, nil
+}
+
+func (d *DnsRecordManager) IsDnsRecordExist(zoneId, name, recordType, content string) (bool, error) {
+	records, err := d.describeDnsRecords(zoneId, []*teo.AdvancedFilter{
+		&teo.AdvancedFilter{
+			Name:   common.StringPtr("name"),
+			Values: common.StringPtrs([]string{name}),
+		},
+		&teo.AdvancedFilter{
+			Name:   common.StringPtr("type"),
+			Values: common.StringPtrs([]string{recordType}),
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, v := range records {
+		if v.Content != nil && *v.Content == content {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (d *DnsRecordManager) CreateDnsRecord(record *DnsRecord) error {
+	ok, err := d.IsDnsRecordExist(*record.ZoneId, *record.Name, *record.Type, *record.Content)
+	if err != nil {
+		return zerr.Wrap(err, "d.IsDnsRecordExist failed")
+	}
+	if ok {
+		return nil
+	}
+	return sendTeoRequest(d.Account, "CreateDnsRecord", record, nil)
+}
@@ -58,6 +58,7 @@ func (z *DomainManager) IsDomainExist(zoneId, host string) (bool, error) {
 	return false, nil
 }
 
+// DescribeDomainListDetail 分页查询站点下的全部加速域名。
 func (z *DomainManager) DescribeDomainListDetail(zoneId string) ([]*teo.AccelerationDomain, error) {
 	credential := common.NewCredential(
 		z.Account.SecretId,
@@ -67,21 +68,31 @@ func (z *DomainManager) DescribeDomainListDetail(zoneId string) ([]*teo.Accelera
 	cpf.HttpProfile.Endpoint = z.Account.EndPoint
 	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
 
-	request := teo.NewDescribeAccelerationDomainsRequest()
-	request.ZoneId = common.StringPtr(zoneId)
-	body, _ := json.Marshal(request)
-	log.Printf("[API] DescribeDomainListDetail Request: %#v", string(body))
-
-	response, err := client.DescribeAccelerationDomains(request)
-	if _, ok := err.(*errors.TencentCloudSDKError); ok {
-		return nil, fmt.Errorf("an API error has returned: %s", err)
-	}
-	if err != nil {
-		return nil, zerr.Wrap(err, "internal error")
+	var list []*teo.AccelerationDomain
+	limit := int64(200)
+	for offset := int64(0); ; offset += limit {
+		request := teo.NewDescribeAccelerationDomainsRequest()
+		request.ZoneId = common.StringPtr(zoneId)
+		request.Offset = common.Int64Ptr(offset)
+		request.Limit = common.Int64Ptr(limit)
+		body, _ := json.Marshal(request)
+		log.Printf("[API] DescribeDomainListDetail Request: %#v", string(body))
+
+		response, err := client.DescribeAccelerationDomains(request)
+		if _, ok := err.(*errors.TencentCloudSDKError); ok {
+			return nil, fmt.Errorf("an API error has returned: %s", err)
+		}
+		if err != nil {
+			return nil, zerr.Wrap(err, "internal error")
+		}
+
+		log.Printf("[API] DescribeDomainListDetail response: %#v\n", response.ToJsonString())
+		list = append(list, response.Response.AccelerationDomains...)
+		if response.Response.TotalCount == nil || offset+limit >= *response.Response.TotalCount {
+			break
+		}
 	}
-
-	log.Printf("[API] DescribeDomainListDetail response: %#v\n", response.ToJsonString())
-	return response.Response.AccelerationDomains, nil
+	return list, nil
 }
 
 func (z *DomainManager) CreateDomain(request *teo.CreateAccelerationDomainRequest) error {
@@ -114,3 +125,131 @@ func (z *DomainManager) CreateDomain(request *teo.CreateAccelerationDomainReques
 	log.Printf("[API] CreateDomain response: %#v", response.ToJsonString())
 	return nil
 }
+
+// DomainConfig 加速域名的回源配置，当前SDK版本的AccelerationDomain中未包含以下字段。
+type DomainConfig struct {
+	DomainName      *string             `json:"DomainName,omitempty"`
+	OriginDetail    *DomainOriginDetail `json:"OriginDetail,omitempty"`
+	OriginProtocol  *string             `json:"OriginProtocol,omitempty"`
+	HttpOriginPort  *uint64             `json:"HttpOriginPort,omitempty"`
+	HttpsOriginPort *uint64             `json:"HttpsOriginPort,omitempty"`
+	IPv6Status      *string             `json:"IPv6Status,omitempty"`
+}
+
+// DomainOriginDetail 加速域名源站信息中当前SDK版本未包含的字段。
+type DomainOriginDetail struct {
+	HostHeader *string `json:"HostHeader,omitempty"`
+}
+
+type describeDomainConfigRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+	Offset *int64  `json:"Offset,omitempty"`
+	Limit  *int64  `json:"Limit,omitempty"`
+}
+
+type describeDomainConfigResponse struct {
+	TotalCount          *int64          `json:"TotalCount,omitempty"`
+	AccelerationDomains []*DomainConfig `json:"AccelerationDomains,omitempty"`
+}
+
+type domainOriginInfo struct {
+	*teo.OriginInfo
+	HostHeader *string `json:"HostHeader,omitempty"`
+}
+
+type modifyDomainConfigRequest struct {
+	ZoneId          *string           `json:"ZoneId,omitempty"`
+	DomainName      *string           `json:"DomainName,omitempty"`
+	OriginInfo      *domainOriginInfo `json:"OriginInfo,omitempty"`
+	OriginProtocol  *string           `json:"OriginProtocol,omitempty"`
+	HttpOriginPort  *uint64           `json:"HttpOriginPort,omitempty"`
+	HttpsOriginPort *uint64           `json:"HttpsOriginPort,omitempty"`
+	IPv6Status      *string           `json:"IPv6Status,omitempty"`
+}
+
+// DescribeDomainConfigList 分页查询站点下全部加速域名的回源配置。
+func (z *DomainManager) DescribeDomainConfigList(zoneId string) ([]*DomainConfig, error) {
+	var list []*DomainConfig
+	limit := int64(200)
+	for offset := int64(0); ; offset += limit {
+		request := &describeDomainConfigRequest{
+			ZoneId: common.StringPtr(zoneId),
+			Offset: common.Int64Ptr(offset),
+			Limit:  common.Int64Ptr(limit),
+		}
+		response := &describeDomainConfigResponse{}
+		if err := sendTeoRequest(z.Account, "DescribeAccelerationDomains", request, response); err != nil {
+			return nil, err
+		}
+		list = append(list, response.AccelerationDomains...)
+		if response.TotalCount == nil || offset+limit >= *response.TotalCount {
+			break
+		}
+	}
+	return list, nil
+}
+
+// ModifyDomainConfig 修改加速域名的源站及回源配置。
+func (z *DomainManager) ModifyDomainConfig(zoneId string, config *DomainConfig, origin *teo.OriginInfo) error {
+	request := &modifyDomainConfigRequest{
+		ZoneId:          common.StringPtr(zoneId),
+		DomainName:      config.DomainName,
+		OriginInfo:      &domainOriginInfo{OriginInfo: origin},
+		OriginProtocol:  config.OriginProtocol,
+		HttpOriginPort:  config.HttpOriginPort,
+		HttpsOriginPort: config.HttpsOriginPort,
+		IPv6Status:      config.IPv6Status,
+	}
+	if config.OriginDetail != nil {
+		request.OriginInfo.HostHeader = config.OriginDetail.HostHeader
+	}
+	return sendTeoRequest(z.Account, "ModifyAccelerationDomain", request, nil)
+}
+
+func (z *DomainManager) ModifyDomain(request *teo.ModifyAccelerationDomainRequest) error {
+	credential := common.NewCredential(
+		z.Account.SecretId,
+		z.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = z.Account.EndPoint
+	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
+	log.Printf("[API] ModifyDomain Request: %v", request.ToJsonString())
+
+	response, err := client.ModifyAccelerationDomain(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] ModifyDomain response: %#v", response.ToJsonString())
+	return nil
+}
+
+// DeleteDomains 删除加速域名，未停用的域名强制删除。
+func (z *DomainManager) DeleteDomains(zoneId string, domainNames []string) error {
+	credential := common.NewCredential(
+		z.Account.SecretId,
+		z.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = z.Account.EndPoint
+	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
+
+	request := teo.NewDeleteAccelerationDomainsRequest()
+	request.ZoneId = common.StringPtr(zoneId)
+	request.DomainNames = common.StringPtrs(domainNames)
+	request.Force = common.BoolPtr(true)
+	log.Printf("[API] DeleteDomains Request: %v", request.ToJsonString())
+
+	response, err := client.DeleteAccelerationDomains(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] DeleteDomains response: %#v", response.ToJsonString())
+	return nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+// Function 边缘函数，当前SDK版本未封装边缘函数相关接口。
+type Function struct {
+	FunctionId *string `json:"FunctionId,omitempty"`
+	ZoneId     *string `json:"ZoneId,omitempty"`
+	Name       *string `json:"Name,omitempty"`
+	Remark     *string `json:"Remark,omitempty"`
+	Content    *string `json:"Content,omitempty"`
+}
+
+// FunctionRule 边缘函数触发规则。
+type FunctionRule struct {
+	RuleId                  *string                   `json:"RuleId,omitempty"`
+	FunctionRuleConditions  []*FunctionRuleCondition  `json:"FunctionRuleConditions,omitempty"`
+	TriggerType             *string                   `json:"TriggerType,omitempty"`
+	FunctionId              *string                   `json:"FunctionId,omitempty"`
+	RegionMappingSelections []*RegionMappingSelection `json:"RegionMappingSelections,omitempty"`
+	Remark                  *string                   `json:"Remark,omitempty"`
+	FunctionName            *string                   `json:"FunctionName,omitempty"`
+	RulePriority            *int64                    `json:"RulePriority,omitempty"`
+}
+
+// RegionMappingSelection 按客户端地区选择执行的函数，TriggerType 为 region 时生效。
+type RegionMappingSelection struct {
+	FunctionId *string   `json:"FunctionId,omitempty"`
+	Regions    []*string `json:"Regions,omitempty"`
+}
+
+// FunctionRuleCondition 边缘函数触发规则条件，条件之间为或关系。
+type FunctionRuleCondition struct {
+	RuleConditions []*teo.RuleCondition `json:"RuleConditions,omitempty"`
+}
+
+type describeFunctionsRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+	Offset *int64  `json:"Offset,omitempty"`
+	Limit  *int64  `json:"Limit,omitempty"`
+}
+
+type describeFunctionsResponse struct {
+	TotalCount *int64      `json:"TotalCount,omitempty"`
+	Functions  []*Function `json:"Functions,omitempty"`
+}
+
+type createFunctionResponse struct {
+	FunctionId *string `json:"FunctionId,omitempty"`
+}
+
+type describeFunctionRulesRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+}
+
+type describeFunctionRulesResponse struct {
+	FunctionRules []*FunctionRule `json:"FunctionRules,omitempty"`
+}
+
+type createFunctionRuleRequest struct {
+	ZoneId                  *string                   `json:"ZoneId,omitempty"`
+	FunctionRuleConditions  []*FunctionRuleCondition  `json:"FunctionRuleConditions,omitempty"`
+	TriggerType             *string                   `json:"TriggerType,omitempty"`
+	FunctionId              *string                   `json:"FunctionId,omitempty"`
+	RegionMappingSelections []*RegionMappingSelection `json:"RegionMappingSelections,omitempty"`
+	Remark                  *string                   `json:"Remark,omitempty"`
+}
+
+// FunctionManager 边缘函数导入。
+type FunctionManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewFunctionManager(a *entity.AccountBaseInfo) *FunctionManager {
+	return &FunctionManager{
+		Account: a,
+	}
+}
+
+func (f *FunctionManager) DescribeFunctionList(zoneId string) ([]*Function, error) {
+	limit := int64(200)
+	request := &describeFunctionsRequest{
+		ZoneId: common.StringPtr(zoneId),
+		Offset: common.Int64Ptr(0),
+		Limit:  common.Int64Ptr(limit),
+	}
+	response := &describeFunctionsResponse{}
+	if err := sendTeoRequest(f.Account, "DescribeFunctions", request, response); err != nil {
+		return nil, err
+	}
+	if response.TotalCount != nil && *response.TotalCount > limit {
+		return nil, fmt.Errorf("the number of functions exceed maximum limit")
+	}
+	return response.Functions, nil
+}
+
+func (f *FunctionManager) GetFunctionIdByName(zoneId, name string) (string, error) {
+	functions, err := f.DescribeFunctionList(zoneId)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range functions {
+		if *v.Name == name {
+			return *v.FunctionId, nil
+		}
+	}
+	return "", nil
+}
+
+func (f *FunctionManager) CreateFunction(zoneId string, function *Function) (string, error) {
+	id, err := f.GetFunctionIdByName(zoneId, *function.Name)
+	if err != nil {
+		return "", zerr.Wrap(err, "f.GetFunctionIdByName failed")
+	}
+	if id != "" {
+		return id, nil
+	}
+	request := &Function{
+		ZoneId:  common.StringPtr(zoneId),
+		Name:    function.Name,
+		Remark:  function.Remark,
+		Content: function.Content,
+	}
+	response := &createFunctionResponse{}
+	if err = sendTeoRequest(f.Account, "CreateFunction", request, response); err != nil {
+		return "", err
+	}
+	return *response.FunctionId, nil
+}
+
+// DescribeFunctionRuleList 查询触发规则，按规则优先级由高到低返回。
+func (f *FunctionManager) DescribeFunctionRuleList(zoneId string) ([]*FunctionRule, error) {
+	request := &describeFunctionRulesRequest{
+		ZoneId: common.StringPtr(zoneId),
+	}
+	response := &describeFunctionRulesResponse{}
+	if err := sendTeoRequest(f.Account, "DescribeFunctionRules", request, response); err != nil {
+		return nil, err
+	}
+	return response.FunctionRules, nil
+}
+
+// CreateFunctionRule 创建触发规则，目标站点已存在触发条件及执行函数均相同的规则时跳过。
+func (f *FunctionManager) CreateFunctionRule(zoneId string, rule *FunctionRule) error {
+	rules, err := f.DescribeFunctionRuleList(zoneId)
+	if err != nil {
+		return zerr.Wrap(err, "f.DescribeFunctionRuleList failed")
+	}
+	for _, v := range rules {
+		same, err := sameFunctionRule(v, rule)
+		if err != nil {
+			return err
+		}
+		if same {
+			return nil
+		}
+	}
+	request := &createFunctionRuleRequest{
+		ZoneId:                  common.StringPtr(zoneId),
+		FunctionRuleConditions:  rule.FunctionRuleConditions,
+		TriggerType:             rule.TriggerType,
+		FunctionId:              rule.FunctionId,
+		RegionMappingSelections: rule.RegionMappingSelections,
+		Remark:                  rule.Remark,
+	}
+	return sendTeoRequest(f.Account, "CreateFunctionRule", request, nil)
+}
+
+// sameFunctionRule 比较两条触发规则的触发条件、触发方式、执行函数及备注是否相同。
+func sameFunctionRule(a, b *FunctionRule) (bool, error) {
+	if stringValue(a.FunctionId) != stringValue(b.FunctionId) || stringValue(a.Remark) != stringValue(b.Remark) {
+		return false, nil
+	}
+	if stringValue(a.TriggerType) != stringValue(b.TriggerType) {
+		return false, nil
+	}
+	for _, v := range [][2]interface{}{
+		{a.FunctionRuleConditions, b.FunctionRuleConditions},
+		{a.RegionMappingSelections, b.RegionMappingSelections},
+	} {
+		x, err := json.Marshal(v[0])
+		if err != nil {
+			return false, err
+		}
+		y, err := json.Marshal(v[1])
+		if err != nil {
+			return false, err
+		}
+		// 接口对空列表可能返回 null 或 []，视为相同
+		if string(x) != string(y) && !(isEmptyJSON(x) && isEmptyJSON(y)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func isEmptyJSON(b []byte) bool {
+	return string(b) == "null" || string(b) == "[]"
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/domain/entity"
+)
+
+// IPGroup IP组，当前SDK版本未封装IP组相关接口。
+type IPGroup struct {
+	GroupId *int64    `json:"GroupId,omitempty"`
+	Name    *string   `json:"Name,omitempty"`
+	Content []*string `json:"Content,omitempty"`
+}
+
+type describeSecurityIPGroupRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+}
+
+type describeSecurityIPGroupResponse struct {
+	IPGroups []*IPGroup `json:"IPGroups,omitempty"`
+}
+
+type createSecurityIPGroupRequest struct {
+	ZoneId  *string  `json:"ZoneId,omitempty"`
+	IPGroup *IPGroup `json:"IPGroup,omitempty"`
+}
+
+type createSecurityIPGroupResponse struct {
+	GroupId *int64 `json:"GroupId,omitempty"`
+}
+
+// IPGroupManager IP组导入。
+type IPGroupManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewIPGroupManager(a *entity.AccountBaseInfo) *IPGroupManager {
+	return &IPGroupManager{
+		Account: a,
+	}
+}
+
+func (i *IPGroupManager) DescribeIPGroupList(zoneId string) ([]*IPGroup, error) {
+	request := &describeSecurityIPGroupRequest{
+		ZoneId: common.StringPtr(zoneId),
+	}
+	response := &describeSecurityIPGroupResponse{}
+	if err := sendTeoRequest(i.Account, "DescribeSecurityIPGroup", request, response); err != nil {
+		return nil, err
+	}
+	return response.IPGroups, nil
+}
+
+func (i *IPGroupManager) GetIPGroupIdByName(zoneId, name string) (int64, error) {
+	groups, err := i.DescribeIPGroupList(zoneId)
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range groups {
+		if *v.Name == name {
+			return *v.GroupId, nil
+		}
+	}
+	return 0, nil
+}
+
+func (i *IPGroupManager) CreateIPGroup(zoneId string, group *IPGroup) (int64, error) {
+	id, err := i.GetIPGroupIdByName(zoneId, *group.Name)
+	if err != nil {
+		return 0, zerr.Wrap(err, "i.GetIPGroupIdByName failed")
+	}
+	if id != 0 {
+		return id, nil
+	}
+	request := &createSecurityIPGroupRequest{
+		ZoneId: common.StringPtr(zoneId),
+		IPGroup: &IPGroup{
+			Name:    group.Name,
+			Content: group.Content,
+		},
+	}
+	response := &createSecurityIPGroupResponse{}
+	if err = sendTeoRequest(i.Account, "CreateSecurityIPGroup", request, response); err != nil {
+		return 0, err
+	}
+	return *response.GroupId, nil
+}
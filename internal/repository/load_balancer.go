@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/domain/entity"
+)
+
+// LoadBalancer 负载均衡实例，当前SDK版本未封装负载均衡相关接口。
+type LoadBalancer struct {
+	InstanceId              *string                    `json:"InstanceId,omitempty"`
+	Name                    *string                    `json:"Name,omitempty"`
+	Type                    *string                    `json:"Type,omitempty"`
+	HealthChecker           json.RawMessage            `json:"HealthChecker,omitempty"`
+	SteeringPolicy          *string                    `json:"SteeringPolicy,omitempty"`
+	FailoverPolicy          *string                    `json:"FailoverPolicy,omitempty"`
+	OriginGroupHealthStatus []*OriginGroupHealthStatus `json:"OriginGroupHealthStatus,omitempty"`
+	Status                  *string                    `json:"Status,omitempty"`
+}
+
+// OriginGroupHealthStatus 负载均衡实例中源站组的信息。
+type OriginGroupHealthStatus struct {
+	OriginGroupID   *string `json:"OriginGroupID,omitempty"`
+	OriginGroupName *string `json:"OriginGroupName,omitempty"`
+	Priority        *string `json:"Priority,omitempty"`
+}
+
+// OriginGroupInLoadBalancer 创建负载均衡实例时的源站组信息。
+type OriginGroupInLoadBalancer struct {
+	Priority      *string `json:"Priority,omitempty"`
+	OriginGroupId *string `json:"OriginGroupId,omitempty"`
+}
+
+type describeLoadBalancerListRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+	Offset *uint64 `json:"Offset,omitempty"`
+	Limit  *uint64 `json:"Limit,omitempty"`
+}
+
+type describeLoadBalancerListResponse struct {
+	TotalCount       *uint64         `json:"TotalCount,omitempty"`
+	LoadBalancerList []*LoadBalancer `json:"LoadBalancerList,omitempty"`
+}
+
+// CreateLoadBalancerRequest 创建负载均衡实例请求。
+type CreateLoadBalancerRequest struct {
+	ZoneId         *string                      `json:"ZoneId,omitempty"`
+	Name           *string                      `json:"Name,omitempty"`
+	Type           *string                      `json:"Type,omitempty"`
+	OriginGroups   []*OriginGroupInLoadBalancer `json:"OriginGroups,omitempty"`
+	HealthChecker  json.RawMessage              `json:"HealthChecker,omitempty"`
+	SteeringPolicy *string                      `json:"SteeringPolicy,omitempty"`
+	FailoverPolicy *string                      `json:"FailoverPolicy,omitempty"`
+}
+
+type createLoadBalancerResponse struct {
+	InstanceId *string `json:"InstanceId,omitempty"`
+}
+
+// LoadBalancerManager 负载均衡导入。
+type LoadBalancerManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewLoadBalancerManager(a *entity.AccountBaseInfo) *LoadBalancerManager {
+	return &LoadBalancerManager{
+		Account: a,
+	}
+}
+
+func (l *LoadBalancerManager) DescribeLoadBalancerList(zoneId string) ([]*LoadBalancer, error) {
+	limit := uint64(100)
+	request := &describeLoadBalancerListRequest{
+		ZoneId: common.StringPtr(zoneId),
+		Offset: common.Uint64Ptr(0),
+		Limit:  common.Uint64Ptr(limit),
+	}
+	response := &describeLoadBalancerListResponse{}
+	if err := sendTeoRequest(l.Account, "DescribeLoadBalancerList", request, response); err != nil {
+		return nil, err
+	}
+	if response.TotalCount != nil && *response.TotalCount > limit {
+		return nil, fmt.Errorf("the number of load balancers exceed maximum limit")
+	}
+	return response.LoadBalancerList, nil
+}
+
+func (l *LoadBalancerManager) GetLoadBalancerIdByName(zoneId, name string) (string, error) {
+	lbs, err := l.DescribeLoadBalancerList(zoneId)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range lbs {
+		if *v.Name == name {
+			return *v.InstanceId, nil
+		}
+	}
+	return "", nil
+}
+
+func (l *LoadBalancerManager) CreateLoadBalancer(request *CreateLoadBalancerRequest) (string, error) {
+	id, err := l.GetLoadBalancerIdByName(*request.ZoneId, *request.Name)
+	if err != nil {
+		return "", zerr.Wrap(err, "l.GetLoadBalancerIdByName failed")
+	}
+	if id != "" {
+		return id, nil
+	}
+	response := &createLoadBalancerResponse{}
+	if err = sendTeoRequest(l.Account, "CreateLoadBalancer", request, response); err != nil {
+		return "", err
+	}
+	return *response.InstanceId, nil
+}
@@ -127,3 +127,49 @@ func (o *OriginManager) CreateOrigin(request *teo.CreateOriginGroupRequest) (str
 	log.Printf("[API] CreateOrigin response: %#v", response.ToJsonString())
 	return *response.Response.OriginGroupId, nil
 }
+
+func (o *OriginManager) ModifyOrigin(request *teo.ModifyOriginGroupRequest) error {
+	credential := common.NewCredential(
+		o.Account.SecretId,
+		o.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = o.Account.EndPoint
+	client, _ := teo.NewClient(credential, o.Account.Region, cpf)
+	log.Printf("[API] ModifyOrigin Request: %#v", request.ToJsonString())
+
+	response, err := client.ModifyOriginGroup(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "interal error")
+	}
+	log.Printf("[API] ModifyOrigin response: %#v", response.ToJsonString())
+	return nil
+}
+
+func (o *OriginManager) DeleteOrigin(zoneId, groupId string) error {
+	credential := common.NewCredential(
+		o.Account.SecretId,
+		o.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = o.Account.EndPoint
+	client, _ := teo.NewClient(credential, o.Account.Region, cpf)
+
+	request := teo.NewDeleteOriginGroupRequest()
+	request.ZoneId = common.StringPtr(zoneId)
+	request.OriginGroupId = common.StringPtr(groupId)
+	log.Printf("[API] DeleteOrigin Request: %#v", request.ToJsonString())
+
+	response, err := client.DeleteOriginGroup(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "interal error")
+	}
+	log.Printf("[API] DeleteOrigin response: %#v", response.ToJsonString())
+	return nil
+}
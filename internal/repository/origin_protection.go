@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+type enableOriginACLRequest struct {
+	ZoneId       *string   `json:"ZoneId,omitempty"`
+	L7EnableMode *string   `json:"L7EnableMode,omitempty"`
+	L7Hosts      []*string `json:"L7Hosts,omitempty"`
+	L4EnableMode *string   `json:"L4EnableMode,omitempty"`
+}
+
+type originACLEntity struct {
+	Type          *string   `json:"Type,omitempty"`
+	Instances     []*string `json:"Instances,omitempty"`
+	OperationMode *string   `json:"OperationMode,omitempty"`
+}
+
+type modifyOriginACLRequest struct {
+	ZoneId            *string            `json:"ZoneId,omitempty"`
+	OriginACLEntities []*originACLEntity `json:"OriginACLEntities,omitempty"`
+}
+
+// OriginProtectionManager 源站防护。
+type OriginProtectionManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewOriginProtectionManager(a *entity.AccountBaseInfo) *OriginProtectionManager {
+	return &OriginProtectionManager{
+		Account: a,
+	}
+}
+
+func (o *OriginProtectionManager) DescribeOriginProtection(zoneId string) (*teo.OriginProtectionInfo, error) {
+	credential := common.NewCredential(
+		o.Account.SecretId,
+		o.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = o.Account.EndPoint
+	client, _ := teo.NewClient(credential, o.Account.Region, cpf)
+
+	request := teo.NewDescribeOriginProtectionRequest()
+	request.ZoneIds = common.StringPtrs([]string{zoneId})
+	log.Printf("[API] DescribeOriginProtection Request: %#v", request.ToJsonString())
+
+	response, err := client.DescribeOriginProtection(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return nil, fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return nil, zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] DescribeOriginProtection response: %#v", response.ToJsonString())
+	if len(response.Response.OriginProtectionInfo) != 1 {
+		return nil, fmt.Errorf("abnormal response")
+	}
+	return response.Response.OriginProtectionInfo[0], nil
+}
+
+// EnableOriginACL 对指定的七层域名开启源站防护，仅适用于源站防护未启用的站点。
+func (o *OriginProtectionManager) EnableOriginACL(zoneId string, hosts []string) error {
+	request := &enableOriginACLRequest{
+		ZoneId:       common.StringPtr(zoneId),
+		L7EnableMode: common.StringPtr("specific"),
+		L7Hosts:      common.StringPtrs(hosts),
+		L4EnableMode: common.StringPtr("none"),
+	}
+	return sendTeoRequest(o.Account, "EnableOriginACL", request, nil)
+}
+
+// AddOriginACLHosts 源站防护已启用时，追加需要防护的七层域名。
+func (o *OriginProtectionManager) AddOriginACLHosts(zoneId string, hosts []string) error {
+	request := &modifyOriginACLRequest{
+		ZoneId: common.StringPtr(zoneId),
+		OriginACLEntities: []*originACLEntity{
+			&originACLEntity{
+				Type:          common.StringPtr("l7"),
+				Instances:     common.StringPtrs(hosts),
+				OperationMode: common.StringPtr("enable"),
+			},
+		},
+	}
+	return sendTeoRequest(o.Account, "ModifyOriginACL", request, nil)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+// Plan 套餐信息，当前SDK版本未封装套餐查询接口。
+type Plan struct {
+	PlanId   *string `json:"PlanId,omitempty"`
+	PlanType *string `json:"PlanType,omitempty"`
+	Area     *string `json:"Area,omitempty"`
+	Status   *string `json:"Status,omitempty"`
+}
+
+type describePlansRequest struct {
+	Filters []*teo.AdvancedFilter `json:"Filters,omitempty"`
+}
+
+type describePlansResponse struct {
+	Plans []*Plan `json:"Plans,omitempty"`
+}
+
+// PlanManager 套餐信息。
+type PlanManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewPlanManager(a *entity.AccountBaseInfo) *PlanManager {
+	return &PlanManager{
+		Account: a,
+	}
+}
+
+func (p *PlanManager) DescribePlan(planId string) (*Plan, error) {
+	request := &describePlansRequest{
+		Filters: []*teo.AdvancedFilter{
+			&teo.AdvancedFilter{
+				Name:   common.StringPtr("plan-id"),
+				Values: common.StringPtrs([]string{planId}),
+			},
+		},
+	}
+	response := &describePlansResponse{}
+	if err := sendTeoRequest(p.Account, "DescribePlans", request, response); err != nil {
+		return nil, err
+	}
+	if len(response.Plans) != 1 {
+		return nil, fmt.Errorf("abnormal response")
+	}
+	return response.Plans[0], nil
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/domain/entity"
+)
+
+// RealtimeLogDeliveryTask 实时日志投递任务，当前SDK版本未封装实时日志相关接口。
+type RealtimeLogDeliveryTask struct {
+	TaskId             *string         `json:"TaskId,omitempty"`
+	TaskName           *string         `json:"TaskName,omitempty"`
+	DeliveryStatus     *string         `json:"DeliveryStatus,omitempty"`
+	TaskType           *string         `json:"TaskType,omitempty"`
+	EntityList         []*string       `json:"EntityList,omitempty"`
+	LogType            *string         `json:"LogType,omitempty"`
+	Area               *string         `json:"Area,omitempty"`
+	Fields             []*string       `json:"Fields,omitempty"`
+	CustomFields       json.RawMessage `json:"CustomFields,omitempty"`
+	DeliveryConditions json.RawMessage `json:"DeliveryConditions,omitempty"`
+	Sample             *uint64         `json:"Sample,omitempty"`
+	LogFormat          json.RawMessage `json:"LogFormat,omitempty"`
+	CLS                *CLSTopic       `json:"CLS,omitempty"`
+	CustomEndpoint     *CustomEndpoint `json:"CustomEndpoint,omitempty"`
+	S3                 *S3             `json:"S3,omitempty"`
+}
+
+// CLSTopic 投递到日志服务的主题信息。
+type CLSTopic struct {
+	LogSetId     *string `json:"LogSetId,omitempty"`
+	TopicId      *string `json:"TopicId,omitempty"`
+	LogSetRegion *string `json:"LogSetRegion,omitempty"`
+}
+
+// CustomEndpoint 投递到自定义HTTP服务的配置。
+type CustomEndpoint struct {
+	Url          *string         `json:"Url,omitempty"`
+	AccessId     *string         `json:"AccessId,omitempty"`
+	AccessKey    *string         `json:"AccessKey,omitempty"`
+	CompressType *string         `json:"CompressType,omitempty"`
+	Protocol     *string         `json:"Protocol,omitempty"`
+	Headers      json.RawMessage `json:"Headers,omitempty"`
+}
+
+// S3 投递到兼容S3协议的对象存储的配置。
+type S3 struct {
+	Endpoint     *string `json:"Endpoint,omitempty"`
+	Region       *string `json:"Region,omitempty"`
+	Bucket       *string `json:"Bucket,omitempty"`
+	AccessId     *string `json:"AccessId,omitempty"`
+	AccessKey    *string `json:"AccessKey,omitempty"`
+	CompressType *string `json:"CompressType,omitempty"`
+}
+
+type describeRealtimeLogDeliveryTasksRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+	Offset *uint64 `json:"Offset,omitempty"`
+	Limit  *uint64 `json:"Limit,omitempty"`
+}
+
+type describeRealtimeLogDeliveryTasksResponse struct {
+	TotalCount               *uint64                    `json:"TotalCount,omitempty"`
+	RealtimeLogDeliveryTasks []*RealtimeLogDeliveryTask `json:"RealtimeLogDeliveryTasks,omitempty"`
+}
+
+type createRealtimeLogDeliveryTaskRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+	*RealtimeLogDeliveryTask
+}
+
+// RealtimeLogManager 实时日志投递任务导入。
+type RealtimeLogManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewRealtimeLogManager(a *entity.AccountBaseInfo) *RealtimeLogManager {
+	return &RealtimeLogManager{
+		Account: a,
+	}
+}
+
+func (r *RealtimeLogManager) DescribeRealtimeLogDeliveryTaskList(zoneId string) ([]*RealtimeLogDeliveryTask, error) {
+	limit := uint64(1000)
+	request := &describeRealtimeLogDeliveryTasksRequest{
+		ZoneId: common.StringPtr(zoneId),
+		Offset: common.Uint64Ptr(0),
+		Limit:  common.Uint64Ptr(limit),
+	}
+	response := &describeRealtimeLogDeliveryTasksResponse{}
+	if err := sendTeoRequest(r.Account, "DescribeRealtimeLogDeliveryTasks", request, response); err != nil {
+		return nil, err
+	}
+	if response.TotalCount != nil && *response.TotalCount > limit {
+		return nil, fmt.Errorf("the number of realtime log delivery tasks exceed maximum limit")
+	}
+	return response.RealtimeLogDeliveryTasks, nil
+}
+
+func (r *RealtimeLogManager) IsRealtimeLogDeliveryTaskExist(zoneId, taskName string) (bool, error) {
+	tasks, err := r.DescribeRealtimeLogDeliveryTaskList(zoneId)
+	if err != nil {
+		return false, err
+	}
+	for _, v := range tasks {
+		if *v.TaskName == taskName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CreateRealtimeLogDeliveryTask 创建实时日志投递任务，task中仅出参使用的字段会被忽略。
+func (r *RealtimeLogManager) CreateRealtimeLogDeliveryTask(zoneId string, task *RealtimeLogDeliveryTask) error {
+	ok, err := r.IsRealtimeLogDeliveryTaskExist(zoneId, *task.TaskName)
+	if err != nil {
+		return zerr.Wrap(err, "r.IsRealtimeLogDeliveryTaskExist failed")
+	}
+	if ok {
+		return nil
+	}
+	t := *task
+	t.TaskId = nil
+	t.DeliveryStatus = nil
+	request := &createRealtimeLogDeliveryTaskRequest{
+		ZoneId:                  common.StringPtr(zoneId),
+		RealtimeLogDeliveryTask: &t,
+	}
+	return sendTeoRequest(r.Account, "CreateRealtimeLogDeliveryTask", request, nil)
+}
@@ -23,6 +23,7 @@ func NewRuleEngineManager(a *entity.AccountBaseInfo) *RuleEngineManager {
 	}
 }
 
+// DescribeRuleList 查询站点下的全部规则，接口不分页，一次返回全部规则。
 func (r *RuleEngineManager) DescribeRuleList(zoneId string) ([]*teo.RuleItem, error) {
 	credential := common.NewCredential(
 		r.Account.SecretId,
@@ -88,3 +89,98 @@ func (r *RuleEngineManager) CreateRule(request *teo.CreateRuleRequest) error {
 	log.Printf("[API] CreateRule response: %#v\n", response.ToJsonString())
 	return nil
 }
+
+func (r *RuleEngineManager) DescribeRulesSetting() ([]*teo.RulesSettingAction, error) {
+	credential := common.NewCredential(
+		r.Account.SecretId,
+		r.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = r.Account.EndPoint
+	client, _ := teo.NewClient(credential, r.Account.Region, cpf)
+	request := teo.NewDescribeRulesSettingRequest()
+	log.Printf("[API] DescribeRulesSetting Request: %#v", request.ToJsonString())
+
+	response, err := client.DescribeRulesSetting(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return nil, fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return nil, zerr.Wrap(err, "interal error")
+	}
+	log.Printf("[API] DescribeRulesSetting response: %#v\n", response.ToJsonString())
+
+	return response.Response.Actions, nil
+}
+
+func (r *RuleEngineManager) ModifyRule(request *teo.ModifyRuleRequest) error {
+	credential := common.NewCredential(
+		r.Account.SecretId,
+		r.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = r.Account.EndPoint
+	client, _ := teo.NewClient(credential, r.Account.Region, cpf)
+	log.Printf("[API] ModifyRule Request: %#v", request.ToJsonString())
+
+	response, err := client.ModifyRule(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %v", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "interal error")
+	}
+	log.Printf("[API] ModifyRule response: %#v\n", response.ToJsonString())
+	return nil
+}
+
+func (r *RuleEngineManager) DeleteRules(zoneId string, ruleIds []string) error {
+	credential := common.NewCredential(
+		r.Account.SecretId,
+		r.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = r.Account.EndPoint
+	client, _ := teo.NewClient(credential, r.Account.Region, cpf)
+
+	request := teo.NewDeleteRulesRequest()
+	request.ZoneId = common.StringPtr(zoneId)
+	request.RuleIds = common.StringPtrs(ruleIds)
+	log.Printf("[API] DeleteRules Request: %#v", request.ToJsonString())
+
+	response, err := client.DeleteRules(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %v", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "interal error")
+	}
+	log.Printf("[API] DeleteRules response: %#v\n", response.ToJsonString())
+	return nil
+}
+
+// ModifyRulePriority 按 ruleIds 的顺序调整规则优先级，需包含站点下的全部规则。
+func (r *RuleEngineManager) ModifyRulePriority(zoneId string, ruleIds []string) error {
+	credential := common.NewCredential(
+		r.Account.SecretId,
+		r.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = r.Account.EndPoint
+	client, _ := teo.NewClient(credential, r.Account.Region, cpf)
+
+	request := teo.NewModifyRulePriorityRequest()
+	request.ZoneId = common.StringPtr(zoneId)
+	request.RuleIds = common.StringPtrs(ruleIds)
+	log.Printf("[API] ModifyRulePriority Request: %#v", request.ToJsonString())
+
+	response, err := client.ModifyRulePriority(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %v", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "interal error")
+	}
+	log.Printf("[API] ModifyRulePriority response: %#v\n", response.ToJsonString())
+	return nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/domain/entity"
+)
+
+// SharedCNAME 共享CNAME及其绑定的域名，当前SDK版本未封装共享CNAME相关接口。
+type SharedCNAME struct {
+	SharedCNAME *string   `json:"SharedCNAME,omitempty"`
+	Description *string   `json:"Description,omitempty"`
+	DomainNames []*string `json:"DomainNames,omitempty"`
+}
+
+type describeSharedCNAMERequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+	Offset *int64  `json:"Offset,omitempty"`
+	Limit  *int64  `json:"Limit,omitempty"`
+}
+
+type describeSharedCNAMEResponse struct {
+	TotalCount   *int64         `json:"TotalCount,omitempty"`
+	SharedCNAMEs []*SharedCNAME `json:"SharedCNAMEs,omitempty"`
+}
+
+type createSharedCNAMERequest struct {
+	ZoneId            *string `json:"ZoneId,omitempty"`
+	SharedCNAMEPrefix *string `json:"SharedCNAMEPrefix,omitempty"`
+	Description       *string `json:"Description,omitempty"`
+}
+
+type createSharedCNAMEResponse struct {
+	SharedCNAME *string `json:"SharedCNAME,omitempty"`
+}
+
+type bindSharedCNAMEMap struct {
+	SharedCNAME *string   `json:"SharedCNAME,omitempty"`
+	DomainNames []*string `json:"DomainNames,omitempty"`
+}
+
+type bindSharedCNAMERequest struct {
+	ZoneId              *string               `json:"ZoneId,omitempty"`
+	BindType            *string               `json:"BindType,omitempty"`
+	BindSharedCNAMEMaps []*bindSharedCNAMEMap `json:"BindSharedCNAMEMaps,omitempty"`
+}
+
+// SharedCNAMEManager 共享CNAME导入。
+type SharedCNAMEManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewSharedCNAMEManager(a *entity.AccountBaseInfo) *SharedCNAMEManager {
+	return &SharedCNAMEManager{
+		Account: a,
+	}
+}
+
+// DescribeSharedCNAMEList 分页查询站点下的全部共享CNAME。
+func (s *SharedCNAMEManager) DescribeSharedCNAMEList(zoneId string) ([]*SharedCNAME, error) {
+	var list []*SharedCNAME
+	limit := int64(200)
+	for offset := int64(0); ; offset += limit {
+		request := &describeSharedCNAMERequest{
+			ZoneId: common.StringPtr(zoneId),
+			Offset: common.Int64Ptr(offset),
+			Limit:  common.Int64Ptr(limit),
+		}
+		response := &describeSharedCNAMEResponse{}
+		if err := sendTeoRequest(s.Account, "DescribeSharedCNAME", request, response); err != nil {
+			return nil, err
+		}
+		list = append(list, response.SharedCNAMEs...)
+		if response.TotalCount == nil || offset+limit >= *response.TotalCount {
+			break
+		}
+	}
+	return list, nil
+}
+
+// CreateSharedCNAME 按前缀创建共享CNAME，返回新建的共享CNAME。
+func (s *SharedCNAMEManager) CreateSharedCNAME(zoneId, prefix string, description *string) (string, error) {
+	request := &createSharedCNAMERequest{
+		ZoneId:            common.StringPtr(zoneId),
+		SharedCNAMEPrefix: common.StringPtr(prefix),
+		Description:       description,
+	}
+	response := &createSharedCNAMEResponse{}
+	if err := sendTeoRequest(s.Account, "CreateSharedCNAME", request, response); err != nil {
+		return "", err
+	}
+	return stringValue(response.SharedCNAME), nil
+}
+
+// BindSharedCNAME 将域名绑定到共享CNAME。
+func (s *SharedCNAMEManager) BindSharedCNAME(zoneId, sharedCNAME string, domainNames []string) error {
+	request := &bindSharedCNAMERequest{
+		ZoneId:   common.StringPtr(zoneId),
+		BindType: common.StringPtr("bind"),
+		BindSharedCNAMEMaps: []*bindSharedCNAMEMap{
+			{
+				SharedCNAME: common.StringPtr(sharedCNAME),
+				DomainNames: common.StringPtrs(domainNames),
+			},
+		},
+	}
+	return sendTeoRequest(s.Account, "BindSharedCNAME", request, nil)
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/mulinbc/zerr"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/errors"
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common/profile"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+// ZoneManager 站点信息。
+type ZoneManager struct {
+	Account *entity.AccountBaseInfo
+}
+
+func NewZoneManager(a *entity.AccountBaseInfo) *ZoneManager {
+	return &ZoneManager{
+		Account: a,
+	}
+}
+
+// DescribeZone 按站点Id查询站点信息，站点不存在时返回nil。
+func (z *ZoneManager) DescribeZone(zoneId string) (*teo.Zone, error) {
+	return z.describeZone("zone-id", zoneId)
+}
+
+func (z *ZoneManager) describeZone(filter, value string) (*teo.Zone, error) {
+	credential := common.NewCredential(
+		z.Account.SecretId,
+		z.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = z.Account.EndPoint
+	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
+
+	request := teo.NewDescribeZonesRequest()
+	request.Filters = []*teo.AdvancedFilter{
+		&teo.AdvancedFilter{
+			Name:   common.StringPtr(filter),
+			Values: common.StringPtrs([]string{value}),
+		},
+	}
+	log.Printf("[API] DescribeZone Request: %#v", request.ToJsonString())
+
+	response, err := client.DescribeZones(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return nil, fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return nil, zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] DescribeZone response: %#v", response.ToJsonString())
+	if *response.Response.TotalCount == 0 {
+		return nil, nil
+	}
+	if *response.Response.TotalCount != 1 {
+		return nil, fmt.Errorf("abnormal response")
+	}
+	return response.Response.Zones[0], nil
+}
+
+// DescribeZoneByName 按站点名称查询站点信息，站点不存在时返回nil。
+func (z *ZoneManager) DescribeZoneByName(zoneName string) (*teo.Zone, error) {
+	return z.describeZone("zone-name", zoneName)
+}
+
+func (z *ZoneManager) CreateZone(request *teo.CreateZoneRequest) (string, error) {
+	credential := common.NewCredential(
+		z.Account.SecretId,
+		z.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = z.Account.EndPoint
+	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
+	log.Printf("[API] CreateZone Request: %#v", request.ToJsonString())
+
+	response, err := client.CreateZone(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return "", fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return "", zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] CreateZone response: %#v", response.ToJsonString())
+	return *response.Response.ZoneId, nil
+}
+
+func (z *ZoneManager) BindZoneToPlan(zoneId, planId string) error {
+	credential := common.NewCredential(
+		z.Account.SecretId,
+		z.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = z.Account.EndPoint
+	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
+
+	request := teo.NewBindZoneToPlanRequest()
+	request.ZoneId = common.StringPtr(zoneId)
+	request.PlanId = common.StringPtr(planId)
+	log.Printf("[API] BindZoneToPlan Request: %#v", request.ToJsonString())
+
+	response, err := client.BindZoneToPlan(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] BindZoneToPlan response: %#v", response.ToJsonString())
+	return nil
+}
+
+// IdentifyZone 发起CNAME接入站点的归属权校验，返回需要添加的DNS校验记录。
+func (z *ZoneManager) IdentifyZone(zoneName string) (*teo.AscriptionInfo, error) {
+	return z.identify(zoneName, "")
+}
+
+// IdentifyDomain 发起站点下加速域名的归属权校验，返回需要添加的DNS校验记录。
+func (z *ZoneManager) IdentifyDomain(zoneName, domain string) (*teo.AscriptionInfo, error) {
+	return z.identify(zoneName, domain)
+}
+
+func (z *ZoneManager) identify(zoneName, domain string) (*teo.AscriptionInfo, error) {
+	credential := common.NewCredential(
+		z.Account.SecretId,
+		z.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = z.Account.EndPoint
+	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
+
+	request := teo.NewIdentifyZoneRequest()
+	request.ZoneName = common.StringPtr(zoneName)
+	if domain != "" {
+		request.Domain = common.StringPtr(domain)
+	}
+	log.Printf("[API] IdentifyZone Request: %#v", request.ToJsonString())
+
+	response, err := client.IdentifyZone(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return nil, fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return nil, zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] IdentifyZone response: %#v", response.ToJsonString())
+	return response.Response.Ascription, nil
+}
+
+// IsZoneIdentified 查询站点归属权是否已校验通过。
+func (z *ZoneManager) IsZoneIdentified(zoneName string) (bool, error) {
+	credential := common.NewCredential(
+		z.Account.SecretId,
+		z.Account.SecretKey,
+	)
+	cpf := profile.NewClientProfile()
+	cpf.HttpProfile.Endpoint = z.Account.EndPoint
+	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
+
+	request := teo.NewDescribeIdentificationsRequest()
+	request.Filters = []*teo.Filter{
+		&teo.Filter{
+			Name:   common.StringPtr("zone-name"),
+			Values: common.StringPtrs([]string{zoneName}),
+		},
+	}
+	log.Printf("[API] IsZoneIdentified Request: %#v", request.ToJsonString())
+
+	response, err := client.DescribeIdentifications(request)
+	if _, ok := err.(*errors.TencentCloudSDKError); ok {
+		return false, fmt.Errorf("an API error has returned: %s", err)
+	}
+	if err != nil {
+		return false, zerr.Wrap(err, "internal error")
+	}
+	log.Printf("[API] IsZoneIdentified response: %#v", response.ToJsonString())
+	for _, v := range response.Response.Identifications {
+		if v.Domain == nil || *v.Domain == "" {
+			return v.Status != nil && *v.Status == "finished", nil
+		}
+	}
+	return false, nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -44,22 +45,32 @@ func (z *ZoneSettingManager) DescribeZoneSetting(zoneId string) (*teo.ZoneSettin
 	return response.Response.ZoneSetting, nil
 }
 
-func (z *ZoneSettingManager) ModifyZoneSetting(request *teo.ModifyZoneSettingRequest) error {
-	credential := common.NewCredential(
-		z.Account.SecretId,
-		z.Account.SecretKey,
-	)
-	cpf := profile.NewClientProfile()
-	cpf.HttpProfile.Endpoint = z.Account.EndPoint
-	client, _ := teo.NewClient(credential, z.Account.Region, cpf)
-	log.Printf("[API] ModifyZoneSetting Request: %#v", request.ToJsonString())
-	response, err := client.ModifyZoneSetting(request)
-	if _, ok := err.(*errors.TencentCloudSDKError); ok {
-		return fmt.Errorf("an API error has returned: %s", err)
+type describeZoneSettingRawRequest struct {
+	ZoneId *string `json:"ZoneId,omitempty"`
+}
+
+type describeZoneSettingRawResponse struct {
+	ZoneSetting map[string]json.RawMessage `json:"ZoneSetting,omitempty"`
+}
+
+// DescribeZoneSettingRaw 按接口原始结构查询站点加速配置，包含当前SDK版本未定义的配置项。
+func (z *ZoneSettingManager) DescribeZoneSettingRaw(zoneId string) (map[string]json.RawMessage, error) {
+	request := &describeZoneSettingRawRequest{
+		ZoneId: common.StringPtr(zoneId),
 	}
-	if err != nil {
-		return zerr.Wrap(err, "internal error")
+	response := &describeZoneSettingRawResponse{}
+	if err := sendTeoRequest(z.Account, "DescribeZoneSetting", request, response); err != nil {
+		return nil, err
+	}
+	return response.ZoneSetting, nil
+}
+
+// ModifyZoneSettingRaw 按配置项名称修改站点加速配置，sections 中的配置项原样提交。
+func (z *ZoneSettingManager) ModifyZoneSettingRaw(zoneId string, sections map[string]json.RawMessage) error {
+	request := make(map[string]interface{}, len(sections)+1)
+	for k, v := range sections {
+		request[k] = v
 	}
-	log.Printf("[API] ModifyZoneSetting response: %#v", response.ToJsonString())
-	return nil
+	request["ZoneId"] = zoneId
+	return sendTeoRequest(z.Account, "ModifyZoneSetting", request, nil)
 }
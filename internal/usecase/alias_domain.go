@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// ImportAliasDomains 别称域名及共享CNAME导入，返回未完整拷贝的配置说明。
+// 托管证书按原证书Id配置，其余类型的证书需在控制台确认。
+func (z *ZoneCopyManager) ImportAliasDomains() ([]string, error) {
+	oldAliases, err := z.aliasDomainImporter.DescribeAliasDomainList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe alias domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	var skipped []string
+	for _, v := range oldAliases {
+		if v.AliasDomain == nil || stringValue(v.Status) == "deleted" {
+			continue
+		}
+		req := teo.NewCreateAliasDomainRequest()
+		req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+		req.AliasName = common.StringPtr(z.getNewName(*v.AliasName))
+		req.TargetName = common.StringPtr(z.getNewName(*v.TargetName))
+		certType := stringValue(v.CertType)
+		if certType == "hosting" && len(v.CertId) > 0 {
+			req.CertType = v.CertType
+			req.CertId = v.CertId
+		}
+		if err = z.aliasDomainImporter.CreateAliasDomain(req); err != nil {
+			log.Printf("alias domain：%v -> %v import failed， err: %v\n", *v.AliasName, *req.AliasName, err)
+			return skipped, err
+		}
+		if certType != "" && certType != "none" && req.CertType == nil {
+			skipped = append(skipped, fmt.Sprintf("alias domain %v: %v certificate not copied", *req.AliasName, certType))
+		}
+	}
+	notes, err := z.importSharedCNAMEs()
+	return append(skipped, notes...), err
+}
+
+// importSharedCNAMEs 按前缀在目标站点创建共享CNAME，并绑定替换后的域名，目标站点已存在同前缀的共享CNAME时直接绑定。
+func (z *ZoneCopyManager) importSharedCNAMEs() ([]string, error) {
+	oldCNAMEs, err := z.sharedCNAMEImporter.DescribeSharedCNAMEList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe shared cname list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return []string{fmt.Sprintf("shared cname configuration not copied, err: %v", err)}, nil
+	}
+	if len(oldCNAMEs) == 0 {
+		return nil, nil
+	}
+	current, err := z.sharedCNAMEImporter.DescribeSharedCNAMEList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe shared cname list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	// 共享CNAME前缀 -> 目标站点的共享CNAME
+	existing := make(map[string]string, len(current))
+	for _, v := range current {
+		existing[sharedCNAMEPrefix(stringValue(v.SharedCNAME))] = stringValue(v.SharedCNAME)
+	}
+	for _, v := range oldCNAMEs {
+		prefix := sharedCNAMEPrefix(stringValue(v.SharedCNAME))
+		cname, ok := existing[prefix]
+		if !ok {
+			if cname, err = z.sharedCNAMEImporter.CreateSharedCNAME(z.config.TargetZoneId, prefix, v.Description); err != nil {
+				log.Printf("shared cname：%v import failed， err: %v\n", stringValue(v.SharedCNAME), err)
+				return nil, err
+			}
+		}
+		var domains []string
+		for _, d := range v.DomainNames {
+			domains = append(domains, z.getNewName(stringValue(d)))
+		}
+		if len(domains) == 0 {
+			continue
+		}
+		if err = z.sharedCNAMEImporter.BindSharedCNAME(z.config.TargetZoneId, cname, domains); err != nil {
+			log.Printf("shared cname：%v bind %v failed， err: %v\n", cname, domains, err)
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// sharedCNAMEPrefix 返回共享CNAME的自定义前缀，即第一段标签。
+func sharedCNAMEPrefix(cname string) string {
+	return strings.SplitN(cname, ".", 2)[0]
+}
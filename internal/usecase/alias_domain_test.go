@@ -0,0 +1,18 @@
+package usecase
+
+import "testing"
+
+func TestSharedCNAMEPrefix(t *testing.T) {
+	cases := []struct {
+		cname, want string
+	}{
+		{"static.a1b2c3.share.dnse5.com", "static"},
+		{"static", "static"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		if got := sharedCNAMEPrefix(c.cname); got != c.want {
+			t.Errorf("sharedCNAMEPrefix(%q) = %q, want %q", c.cname, got, c.want)
+		}
+	}
+}
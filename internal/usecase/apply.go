@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+// Apply 将目标站点收敛到期望状态：创建缺失的对象，修改与期望不一致的对象；
+// prune为true时删除期望状态中未定义的源站组、加速域名及规则，删除前通过confirm确认。每项变更通过notify输出。
+func (z *ZoneCopyManager) Apply(state *entity.DesiredState, prune bool, confirm ConfirmFunc, notify func(msg string)) error {
+	groupIds, err := z.applyOriginGroups(state.OriginGroups, notify)
+	if err != nil {
+		return err
+	}
+	if err = z.applyDomains(state.Domains, groupIds, notify); err != nil {
+		return err
+	}
+	if err = z.applyRules(state.Rules, groupIds, notify); err != nil {
+		return err
+	}
+	if state.ZoneSetting != nil {
+		if err = z.applyZoneSetting(state.ZoneSetting, notify); err != nil {
+			return err
+		}
+	}
+	if !prune {
+		return nil
+	}
+	// 按依赖关系逆序删除：规则、域名引用源站组
+	keepRules := make(map[string]bool)
+	for _, v := range state.Rules {
+		keepRules[*v.RuleName] = true
+	}
+	if err = z.pruneRules(keepRules, confirm, notify); err != nil {
+		return err
+	}
+	keepDomains := make(map[string]bool)
+	for _, v := range state.Domains {
+		keepDomains[*v.DomainName] = true
+	}
+	if err = z.pruneDomains(keepDomains, confirm, notify); err != nil {
+		return err
+	}
+	keepGroups := make(map[string]bool)
+	for _, v := range state.OriginGroups {
+		keepGroups[*v.OriginGroupName] = true
+	}
+	return z.pruneOriginGroups(keepGroups, confirm, notify)
+}
+
+// applyOriginGroups 按名称收敛源站组，返回目标站点中源站组名称到Id的映射。
+func (z *ZoneCopyManager) applyOriginGroups(groups []*teo.OriginGroup, notify func(msg string)) (map[string]string, error) {
+	current, err := z.originImporter.DescribeOriginGroupList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	groupIds := make(map[string]string)
+	existing := make(map[string]*teo.OriginGroup)
+	for _, v := range current {
+		groupIds[*v.OriginGroupName] = *v.OriginGroupId
+		existing[*v.OriginGroupName] = v
+	}
+	for _, v := range groups {
+		old, ok := existing[*v.OriginGroupName]
+		if !ok {
+			req := teo.NewCreateOriginGroupRequest()
+			req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+			req.OriginType = v.OriginType
+			req.OriginGroupName = v.OriginGroupName
+			req.ConfigurationType = v.ConfigurationType
+			req.OriginRecords = v.OriginRecords
+			req.HostHeader = v.HostHeader
+			id, err := z.originImporter.CreateOrigin(req)
+			if err != nil {
+				log.Printf("origin group: %v create failed, err: %v\n", *v.OriginGroupName, err)
+				return nil, err
+			}
+			groupIds[*v.OriginGroupName] = id
+			notify(fmt.Sprintf("origin group %v created", *v.OriginGroupName))
+			continue
+		}
+		if sameConfig(originGroupState(old), originGroupState(v)) {
+			continue
+		}
+		req := teo.NewModifyOriginGroupRequest()
+		req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+		req.OriginGroupId = old.OriginGroupId
+		req.OriginType = v.OriginType
+		req.OriginGroupName = v.OriginGroupName
+		req.ConfigurationType = v.ConfigurationType
+		req.OriginRecords = v.OriginRecords
+		req.HostHeader = v.HostHeader
+		if err = z.originImporter.ModifyOrigin(req); err != nil {
+			log.Printf("origin group: %v modify failed, err: %v\n", *v.OriginGroupName, err)
+			return nil, err
+		}
+		notify(fmt.Sprintf("origin group %v modified", *v.OriginGroupName))
+	}
+	return groupIds, nil
+}
+
+// originGroupState 源站组中参与比较的字段，忽略源站记录Id等只读字段。
+func originGroupState(g *teo.OriginGroup) *teo.OriginGroup {
+	records := make([]*teo.OriginRecord, 0, len(g.OriginRecords))
+	for _, v := range g.OriginRecords {
+		r := *v
+		r.RecordId = nil
+		records = append(records, &r)
+	}
+	return &teo.OriginGroup{
+		OriginType:        g.OriginType,
+		ConfigurationType: g.ConfigurationType,
+		OriginRecords:     records,
+		HostHeader:        g.HostHeader,
+	}
+}
+
+// applyDomains 按域名收敛加速域名的源站配置，源站组名称替换为目标站点中的源站组Id。
+func (z *ZoneCopyManager) applyDomains(domains []*entity.DesiredDomain, groupIds map[string]string, notify func(msg string)) error {
+	current, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	existing := make(map[string]*teo.AccelerationDomain)
+	for _, v := range current {
+		existing[*v.DomainName] = v
+	}
+	for _, v := range domains {
+		origin := *v.OriginInfo
+		if origin.OriginType != nil && *origin.OriginType == "ORIGIN_GROUP" {
+			if origin.Origin, err = resolveGroupName(groupIds, origin.Origin); err != nil {
+				return fmt.Errorf("domain %v origin: %v", *v.DomainName, err)
+			}
+			if origin.BackupOrigin, err = resolveGroupName(groupIds, origin.BackupOrigin); err != nil {
+				return fmt.Errorf("domain %v backup origin: %v", *v.DomainName, err)
+			}
+		}
+		old, ok := existing[*v.DomainName]
+		if !ok {
+			req := teo.NewCreateAccelerationDomainRequest()
+			req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+			req.DomainName = v.DomainName
+			req.OriginInfo = &origin
+			if err = z.domainImporter.CreateDomain(req); err != nil {
+				log.Printf("domain: %v create failed, err: %v\n", *v.DomainName, err)
+				return err
+			}
+			notify(fmt.Sprintf("domain %v created", *v.DomainName))
+			continue
+		}
+		if old.OriginDetail != nil && sameConfig(&origin, &teo.OriginInfo{
+			OriginType:        old.OriginDetail.OriginType,
+			Origin:            old.OriginDetail.Origin,
+			BackupOrigin:      old.OriginDetail.BackupOrigin,
+			PrivateAccess:     old.OriginDetail.PrivateAccess,
+			PrivateParameters: old.OriginDetail.PrivateParameters,
+		}) {
+			continue
+		}
+		req := teo.NewModifyAccelerationDomainRequest()
+		req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+		req.DomainName = v.DomainName
+		req.OriginInfo = &origin
+		if err = z.domainImporter.ModifyDomain(req); err != nil {
+			log.Printf("domain: %v modify failed, err: %v\n", *v.DomainName, err)
+			return err
+		}
+		notify(fmt.Sprintf("domain %v modified", *v.DomainName))
+	}
+	return nil
+}
+
+// applyRules 按规则名称收敛规则引擎规则，Origin 操作中的源站组名称替换为目标站点中的源站组Id。
+func (z *ZoneCopyManager) applyRules(rules []*teo.RuleItem, groupIds map[string]string, notify func(msg string)) error {
+	current, err := z.ruleImporter.DescribeRuleList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	existing := make(map[string]*teo.RuleItem)
+	for _, v := range current {
+		existing[*v.RuleName] = v
+	}
+	// 新建的规则排在最前，逆序处理使目标站点中的顺序与文件一致
+	for i := len(rules) - 1; i >= 0; i-- {
+		v := rules[i]
+		if err = resolveRuleGroupNames(v.Rules, groupIds); err != nil {
+			return fmt.Errorf("rule %v: %v", *v.RuleName, err)
+		}
+		old, ok := existing[*v.RuleName]
+		if !ok {
+			req := teo.NewCreateRuleRequest()
+			req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+			req.RuleName = v.RuleName
+			req.Status = v.Status
+			req.Rules = v.Rules
+			req.Tags = v.Tags
+			if err = z.ruleImporter.CreateRule(req); err != nil {
+				log.Printf("rule: %v create failed, err: %v\n", *v.RuleName, err)
+				return err
+			}
+			notify(fmt.Sprintf("rule %v created", *v.RuleName))
+			continue
+		}
+		if sameConfig(&teo.RuleItem{Status: old.Status, Rules: old.Rules, Tags: old.Tags},
+			&teo.RuleItem{Status: v.Status, Rules: v.Rules, Tags: v.Tags}) {
+			continue
+		}
+		req := teo.NewModifyRuleRequest()
+		req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+		req.RuleId = old.RuleId
+		req.RuleName = v.RuleName
+		req.Status = v.Status
+		req.Rules = v.Rules
+		req.Tags = v.Tags
+		if err = z.ruleImporter.ModifyRule(req); err != nil {
+			log.Printf("rule: %v modify failed, err: %v\n", *v.RuleName, err)
+			return err
+		}
+		notify(fmt.Sprintf("rule %v modified", *v.RuleName))
+	}
+	return nil
+}
+
+// applyZoneSetting 仅修改期望状态中已定义且与当前配置不一致的站点加速配置项，
+// 配置项中未定义的字段保持目标站点当前的取值。
+func (z *ZoneCopyManager) applyZoneSetting(desired *teo.ZoneSetting, notify func(msg string)) error {
+	current, err := z.zoneSettingImporter.DescribeZoneSettingRaw(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone setting failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	des := zoneSettingSections(toSections(desired))
+	drifted := zoneSettingDiff(current, des)
+	if len(drifted) == 0 {
+		return nil
+	}
+	sections := make(map[string]json.RawMessage, len(drifted))
+	for _, v := range drifted {
+		sections[v] = mergeJSON(current[v], des[v])
+	}
+	skipped, err := z.modifyZoneSetting(sections)
+	if err != nil {
+		return err
+	}
+	if len(skipped) > 0 {
+		return fmt.Errorf("zone setting %v can not be applied by current api version", skipped)
+	}
+	notify(fmt.Sprintf("zone setting %v modified", drifted))
+	return nil
+}
+
+// resolveGroupName 将源站组名称替换为源站组Id，已是Id时保持不变。
+func resolveGroupName(groupIds map[string]string, name *string) (*string, error) {
+	if name == nil || *name == "" {
+		return name, nil
+	}
+	if id, ok := groupIds[*name]; ok {
+		return common.StringPtr(id), nil
+	}
+	for _, id := range groupIds {
+		if id == *name {
+			return name, nil
+		}
+	}
+	return nil, fmt.Errorf("origin group %v not found", *name)
+}
+
+func resolveRuleGroupNames(rules []*teo.Rule, groupIds map[string]string) error {
+	var actions []*teo.Action
+	for _, r := range rules {
+		actions = append(actions, r.Actions...)
+		for _, sub := range r.SubRules {
+			for _, s := range sub.Rules {
+				actions = append(actions, s.Actions...)
+			}
+		}
+	}
+	for _, a := range actions {
+		if a.NormalAction == nil || a.NormalAction.Action == nil || *a.NormalAction.Action != "Origin" {
+			continue
+		}
+		for _, p := range a.NormalAction.Parameters {
+			if p.Name == nil || *p.Name != "OriginGroupId" {
+				continue
+			}
+			for i, v := range p.Values {
+				id, err := resolveGroupName(groupIds, v)
+				if err != nil {
+					return err
+				}
+				p.Values[i] = id
+			}
+		}
+	}
+	return nil
+}
+
+// zoneSettingDiff 返回期望状态中已定义且与当前配置不一致的配置项名称，只比较期望状态中定义的字段。
+func zoneSettingDiff(current, desired map[string]json.RawMessage) []string {
+	var drifted []string
+	for k, v := range desired {
+		if zoneSettingMeta[k] {
+			continue
+		}
+		if !sameJSON(current[k], mergeJSON(current[k], v)) {
+			drifted = append(drifted, k)
+		}
+	}
+	sort.Strings(drifted)
+	return drifted
+}
+
+// toSections 将站点配置按配置项名称拆分为json片段。
+func toSections(sets *teo.ZoneSetting) map[string]json.RawMessage {
+	var sections map[string]json.RawMessage
+	body, _ := json.Marshal(sets)
+	_ = json.Unmarshal(body, &sections)
+	return sections
+}
+
+// mergeJSON 将desired中定义的字段逐层覆盖到current上，非对象的值直接使用desired。
+func mergeJSON(current, desired json.RawMessage) json.RawMessage {
+	var cur, des map[string]json.RawMessage
+	if json.Unmarshal(current, &cur) != nil || json.Unmarshal(desired, &des) != nil || cur == nil || des == nil {
+		return desired
+	}
+	for k, v := range des {
+		cur[k] = mergeJSON(cur[k], v)
+	}
+	body, err := json.Marshal(cur)
+	if err != nil {
+		return desired
+	}
+	return body
+}
+
+// sameJSON 忽略字段顺序及空白比较两个json片段。
+func sameJSON(a, b json.RawMessage) bool {
+	var av, bv interface{}
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return string(a) == string(b)
+	}
+	return sameConfig(av, bv)
+}
+
+// sameConfig 按json序列化结果比较两份配置是否一致。
+func sameConfig(a, b interface{}) bool {
+	ab, _ := json.Marshal(a)
+	bb, _ := json.Marshal(b)
+	return string(ab) == string(bb)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+)
+
+func TestResolveGroupName(t *testing.T) {
+	groupIds := map[string]string{"main": "origin-1", "backup": "origin-2"}
+	cases := []struct {
+		name    string
+		in      *string
+		want    *string
+		wantErr bool
+	}{
+		{"nil", nil, nil, false},
+		{"empty", common.StringPtr(""), common.StringPtr(""), false},
+		{"by name", common.StringPtr("main"), common.StringPtr("origin-1"), false},
+		{"already id", common.StringPtr("origin-2"), common.StringPtr("origin-2"), false},
+		{"unknown", common.StringPtr("missing"), nil, true},
+	}
+	for _, c := range cases {
+		got, err := resolveGroupName(groupIds, c.in)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%v: err = %v, wantErr %v", c.name, err, c.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: resolveGroupName() = %q, want %q", c.name, stringValue(got), stringValue(c.want))
+		}
+	}
+}
+
+func TestZoneSettingDiff(t *testing.T) {
+	cases := []struct {
+		name             string
+		current, desired map[string]json.RawMessage
+		want             []string
+	}{
+		{
+			name:    "same",
+			current: map[string]json.RawMessage{"Quic": json.RawMessage(`{"Switch":"on"}`)},
+			desired: map[string]json.RawMessage{"Quic": json.RawMessage(`{"Switch":"on"}`)},
+		},
+		{
+			name:    "field order and spaces ignored",
+			current: map[string]json.RawMessage{"CacheKey": json.RawMessage(`{"FullUrlCache":"on","IgnoreCase":"off"}`)},
+			desired: map[string]json.RawMessage{"CacheKey": json.RawMessage(`{ "IgnoreCase": "off", "FullUrlCache": "on" }`)},
+		},
+		{
+			name:    "changed and missing",
+			current: map[string]json.RawMessage{"Quic": json.RawMessage(`{"Switch":"off"}`)},
+			desired: map[string]json.RawMessage{
+				"Quic":          json.RawMessage(`{"Switch":"on"}`),
+				"ImageOptimize": json.RawMessage(`{"WebP":{"Switch":"on"}}`),
+			},
+			want: []string{"ImageOptimize", "Quic"},
+		},
+		{
+			name:    "meta fields ignored",
+			current: map[string]json.RawMessage{"ZoneName": json.RawMessage(`"a.com"`)},
+			desired: map[string]json.RawMessage{"ZoneName": json.RawMessage(`"b.com"`), "Area": json.RawMessage(`"global"`)},
+		},
+		{
+			name: "partial section matches",
+			current: map[string]json.RawMessage{
+				"CacheConfig": json.RawMessage(`{"Cache":{"Switch":"on","CacheTime":3600},"NoCache":{"Switch":"off"},"FollowOrigin":{"Switch":"off"}}`),
+			},
+			desired: map[string]json.RawMessage{"CacheConfig": json.RawMessage(`{"Cache":{"Switch":"on"}}`)},
+		},
+		{
+			name: "partial section changed",
+			current: map[string]json.RawMessage{
+				"CacheConfig": json.RawMessage(`{"Cache":{"Switch":"on","CacheTime":3600},"NoCache":{"Switch":"off"}}`),
+			},
+			desired: map[string]json.RawMessage{"CacheConfig": json.RawMessage(`{"Cache":{"CacheTime":600}}`)},
+			want:    []string{"CacheConfig"},
+		},
+		{
+			name:    "sections not in desired are kept",
+			current: map[string]json.RawMessage{"Grpc": json.RawMessage(`{"Switch":"on"}`)},
+			desired: map[string]json.RawMessage{},
+		},
+	}
+	for _, c := range cases {
+		if got := zoneSettingDiff(c.current, c.desired); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: zoneSettingDiff() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestMergeJSON(t *testing.T) {
+	cases := []struct {
+		name, current, desired, want string
+	}{
+		{"nested fields kept", `{"Cache":{"Switch":"on","CacheTime":3600},"NoCache":{"Switch":"off"}}`, `{"Cache":{"CacheTime":600}}`,
+			`{"Cache":{"CacheTime":600,"Switch":"on"},"NoCache":{"Switch":"off"}}`},
+		{"new field added", `{"Switch":"on"}`, `{"Mode":"all"}`, `{"Mode":"all","Switch":"on"}`},
+		{"array replaced", `{"Values":["a","b"]}`, `{"Values":["c"]}`, `{"Values":["c"]}`},
+		{"current missing", ``, `{"Switch":"on"}`, `{"Switch":"on"}`},
+		{"current null", `null`, `{"Switch":"on"}`, `{"Switch":"on"}`},
+	}
+	for _, c := range cases {
+		got := mergeJSON(json.RawMessage(c.current), json.RawMessage(c.desired))
+		if !sameJSON(got, json.RawMessage(c.want)) {
+			t.Errorf("%v: mergeJSON() = %s, want %s", c.name, got, c.want)
+		}
+	}
+}
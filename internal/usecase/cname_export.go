@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ExportCnameRecords 按目标站点的加速域名生成需要在外部DNS服务商添加的CNAME记录，
+// 输出 <目标站点>.cname.zone (BIND格式) 及 <目标站点>.cname.csv 两份文件，仅CNAME接入站点可用。
+func (z *ZoneCopyManager) ExportCnameRecords(outputDir string) ([]string, error) {
+	zone, err := z.zoneImporter.DescribeZone(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	if zone == nil || *zone.Type != "partial" {
+		return nil, fmt.Errorf("zone %v is not in cname access mode", z.config.TargetZone)
+	}
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	if err = os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, err
+	}
+	name := filepath.Join(outputDir, z.config.TargetZone+".cname")
+	zoneFile, csvFile := name+".zone", name+".csv"
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %v.\n", z.config.TargetZone)
+	rows := [][]string{{"Host", "Type", "Value", "TTL"}}
+	for _, v := range domains {
+		if v.Cname == nil || *v.Cname == "" {
+			continue
+		}
+		b.WriteString(z.cnameZoneLine(*v.DomainName, *v.Cname))
+		rows = append(rows, []string{z.relativeHost(*v.DomainName), "CNAME", strings.TrimSuffix(*v.Cname, "."), strconv.Itoa(defaultRecordTTL)})
+	}
+	if err = os.WriteFile(zoneFile, []byte(b.String()), 0644); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(csvFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err = w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return []string{zoneFile, csvFile}, nil
+}
+
+// relativeHost 返回域名相对于目标站点的主机记录，站点根域名为 @。
+func (z *ZoneCopyManager) relativeHost(host string) string {
+	if host == z.config.TargetZone {
+		return "@"
+	}
+	return strings.TrimSuffix(host, "."+z.config.TargetZone)
+}
+
+// cnameZoneLine 生成加速域名的CNAME记录，站点根域名不能设置CNAME记录，以注释形式输出并提示使用ALIAS或CNAME拉平。
+func (z *ZoneCopyManager) cnameZoneLine(host, cname string) string {
+	line := zoneFileLine(host, "CNAME", cname)
+	if host != z.config.TargetZone {
+		return line
+	}
+	return fmt.Sprintf("; apex can not be a CNAME, add an ALIAS or CNAME flattening record at your DNS provider:\n; %v", line)
+}
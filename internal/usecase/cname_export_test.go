@@ -0,0 +1,18 @@
+package usecase
+
+import "testing"
+
+func TestCnameZoneLine(t *testing.T) {
+	z := newTestManager()
+	cases := []struct {
+		host, want string
+	}{
+		{"www.example.com", "www.example.com.\t600\tIN\tCNAME\twww.example.com.eo.dnse2.com.\n"},
+		{"example.com", "; apex can not be a CNAME, add an ALIAS or CNAME flattening record at your DNS provider:\n; example.com.\t600\tIN\tCNAME\texample.com.eo.dnse2.com.\n"},
+	}
+	for _, c := range cases {
+		if got := z.cnameZoneLine(c.host, c.host+".eo.dnse2.com"); got != c.want {
+			t.Errorf("cnameZoneLine(%q) = %q, want %q", c.host, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+)
+
+// ImportContentIdentifiers 内容标识符导入，模板站点与目标站点属于同一套餐时无需导入。
+func (z *ZoneCopyManager) ImportContentIdentifiers() error {
+	oldPlan, err := z.getPlanId(z.config.TemplateZoneId)
+	if err != nil {
+		return err
+	}
+	newPlan, err := z.getPlanId(z.config.TargetZoneId)
+	if err != nil {
+		return err
+	}
+	if oldPlan == newPlan {
+		log.Printf("plan id: %v is shared by template and target zone, skip content identifier import\n", oldPlan)
+		return nil
+	}
+	oldContents, err := z.contentImporter.DescribeContentIdentifierList(oldPlan)
+	if err != nil {
+		log.Printf("plan id: %v describe content identifier failed, err: %v\n", oldPlan, err)
+		return err
+	}
+	for _, v := range oldContents {
+		if v.Status != nil && *v.Status == "deleted" {
+			continue
+		}
+		// 按描述建立新旧站点的映射，无描述的内容标识符无法映射
+		if v.Description == nil || *v.Description == "" {
+			log.Printf("content identifier：%v has no description, skipped\n", stringValue(v.ContentId))
+			continue
+		}
+		id, err := z.contentImporter.CreateContentIdentifier(newPlan, v)
+		if err != nil {
+			log.Printf("content identifier：%v import failed, err: %v\n", *v.Description, err)
+			return err
+		}
+		if z.isContentInit {
+			z.templateContent[*v.ContentId] = *v.Description
+			z.targetContent[*v.Description] = id
+		}
+	}
+	return nil
+}
+
+// getPlanId 获取站点绑定的套餐Id。
+func (z *ZoneCopyManager) getPlanId(zoneId string) (string, error) {
+	zone, err := z.zoneImporter.DescribeZone(zoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone failed, err: %v\n", zoneId, err)
+		return "", err
+	}
+	if zone == nil {
+		return "", fmt.Errorf("zone %v not found", zoneId)
+	}
+	for _, v := range zone.Resources {
+		if v.PlanId != nil && *v.PlanId != "" {
+			return *v.PlanId, nil
+		}
+	}
+	return "", fmt.Errorf("zone %v is not bound to any plan", zoneId)
+}
+
+// initContentMap 初始化新旧站点内容标识符的映射关系。
+func (z *ZoneCopyManager) initContentMap() error {
+	if z.isContentInit {
+		return nil
+	}
+	oldPlan, err := z.getPlanId(z.config.TemplateZoneId)
+	if err != nil {
+		return err
+	}
+	newPlan, err := z.getPlanId(z.config.TargetZoneId)
+	if err != nil {
+		return err
+	}
+	oldContents, err := z.contentImporter.DescribeContentIdentifierList(oldPlan)
+	if err != nil {
+		log.Printf("plan id: %v describe content identifier failed, err: %v\n", oldPlan, err)
+		return err
+	}
+	for _, v := range oldContents {
+		if v.Description == nil {
+			continue
+		}
+		z.templateContent[*v.ContentId] = *v.Description
+	}
+	newContents, err := z.contentImporter.DescribeContentIdentifierList(newPlan)
+	if err != nil {
+		log.Printf("plan id: %v describe content identifier failed, err: %v\n", newPlan, err)
+		return err
+	}
+	for _, v := range newContents {
+		if v.Description == nil {
+			continue
+		}
+		z.targetContent[*v.Description] = *v.ContentId
+	}
+	z.isContentInit = true
+	return nil
+}
+
+// getNewContentId 旧站点内容标识符Id转换为新站点内容标识符Id。
+func (z *ZoneCopyManager) getNewContentId(old string) (string, error) {
+	if err := z.initContentMap(); err != nil {
+		return "", err
+	}
+	desc, ok := z.templateContent[old]
+	if !ok {
+		log.Printf("zoneId: %v not find old content identifier: %v", z.config.TargetZoneId, old)
+		return "", fmt.Errorf("not find old content identifier")
+	}
+	id, ok := z.targetContent[desc]
+	if !ok {
+		log.Printf("zoneId: %v not find new content identifier: %v", z.config.TargetZoneId, old)
+		return "", fmt.Errorf("not find new content identifier")
+	}
+	return id, nil
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 
@@ -13,15 +14,40 @@ import (
 
 // ZoneCopyManager 站点配置拷贝。
 type ZoneCopyManager struct {
-	config              *entity.ZoneCopyConfig
-	originImporter      *repository.OriginManager
-	domainImporter      *repository.DomainManager
-	ruleImporter        *repository.RuleEngineManager
-	zoneSettingImporter *repository.ZoneSettingManager
+	config                   *entity.ZoneCopyConfig
+	originImporter           *repository.OriginManager
+	domainImporter           *repository.DomainManager
+	ruleImporter             *repository.RuleEngineManager
+	zoneSettingImporter      *repository.ZoneSettingManager
+	aliasDomainImporter      *repository.AliasDomainManager
+	sharedCNAMEImporter      *repository.SharedCNAMEManager
+	dnsRecordImporter        *repository.DnsRecordManager
+	zoneImporter             *repository.ZoneManager
+	ipGroupImporter          *repository.IPGroupManager
+	realtimeLogImporter      *repository.RealtimeLogManager
+	functionImporter         *repository.FunctionManager
+	loadBalancerImporter     *repository.LoadBalancerManager
+	originProtectionImporter *repository.OriginProtectionManager
+	contentImporter          *repository.ContentIdentifierManager
+	planImporter             *repository.PlanManager
 
 	isOriginInit   bool              // 标识以下两个源站组配置信息是否初始化了
 	templateOrigin map[string]string // 旧的groupId -> groupName
 	targetOrigin   map[string]string // 新的groupName -> groupId
+
+	isIPGroupInit   bool             // 标识以下两个IP组配置信息是否初始化了
+	templateIPGroup map[int64]string // 旧的IP组Id -> IP组名称
+	targetIPGroup   map[string]int64 // 新的IP组名称 -> IP组Id
+
+	isLBInit   bool              // 标识以下两个负载均衡配置信息是否初始化了
+	templateLB map[string]string // 旧的实例Id -> 实例名称
+	targetLB   map[string]string // 新的实例名称 -> 实例Id
+
+	isContentInit   bool              // 标识以下两个内容标识符配置信息是否初始化了
+	templateContent map[string]string // 旧的内容标识符Id -> 描述
+	targetContent   map[string]string // 新的描述 -> 内容标识符Id
+
+	ruleActions map[string]bool // 规则引擎支持的操作名称，为nil时表示未初始化
 }
 
 func NewZoneCopyManager(c *entity.ZoneCopyConfig) *ZoneCopyManager {
@@ -30,33 +56,52 @@ func NewZoneCopyManager(c *entity.ZoneCopyConfig) *ZoneCopyManager {
 		return nil
 	}
 	return &ZoneCopyManager{
-		config:              c,
-		originImporter:      repository.NewOriginManager(c.Account),
-		domainImporter:      repository.NewDomainManager(c.Account),
-		ruleImporter:        repository.NewRuleEngineManager(c.Account),
-		zoneSettingImporter: repository.NewZoneSettingManager(c.Account),
+		config:                   c,
+		originImporter:           repository.NewOriginManager(c.Account),
+		domainImporter:           repository.NewDomainManager(c.Account),
+		ruleImporter:             repository.NewRuleEngineManager(c.Account),
+		zoneSettingImporter:      repository.NewZoneSettingManager(c.Account),
+		aliasDomainImporter:      repository.NewAliasDomainManager(c.Account),
+		sharedCNAMEImporter:      repository.NewSharedCNAMEManager(c.Account),
+		dnsRecordImporter:        repository.NewDnsRecordManager(c.Account),
+		zoneImporter:             repository.NewZoneManager(c.Account),
+		ipGroupImporter:          repository.NewIPGroupManager(c.Account),
+		realtimeLogImporter:      repository.NewRealtimeLogManager(c.Account),
+		functionImporter:         repository.NewFunctionManager(c.Account),
+		loadBalancerImporter:     repository.NewLoadBalancerManager(c.Account),
+		originProtectionImporter: repository.NewOriginProtectionManager(c.Account),
+		contentImporter:          repository.NewContentIdentifierManager(c.Account),
+		planImporter:             repository.NewPlanManager(c.Account),
 
 		isOriginInit:   false,
 		templateOrigin: make(map[string]string),
 		targetOrigin:   make(map[string]string),
+
+		isIPGroupInit:   false,
+		templateIPGroup: make(map[int64]string),
+		targetIPGroup:   make(map[string]int64),
+
+		isLBInit:   false,
+		templateLB: make(map[string]string),
+		targetLB:   make(map[string]string),
+
+		isContentInit:   false,
+		templateContent: make(map[string]string),
+		targetContent:   make(map[string]string),
 	}
 }
 
 // ImportOrigin 源站导入。
 func (z *ZoneCopyManager) ImportOrigin() error {
+	// 导入后目标站点的源站组已变化，之前缓存的映射需重新查询
+	defer z.resetOriginMap()
 	oldGroups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
 	if err != nil {
 		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
 		return err
 	}
 	for _, v := range oldGroups {
-		req := teo.NewCreateOriginGroupRequest()
-		req.ZoneId = common.StringPtr(z.config.TargetZoneId)
-		req.OriginType = v.OriginType
-		req.OriginGroupName = v.OriginGroupName
-		req.ConfigurationType = v.ConfigurationType
-		req.OriginRecords = v.OriginRecords
-		req.HostHeader = v.HostHeader
+		req := z.buildOriginGroupRequest(v)
 		_, err = z.originImporter.CreateOrigin(req)
 		if err != nil {
 			log.Printf("origin：%v import failed, err: %v\n", req.OriginGroupName, err)
@@ -73,7 +118,15 @@ func (z *ZoneCopyManager) ImportDomains() error {
 		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
 		return err
 	}
-	// TODO：验证对象存储源站是否正常
+	configs, err := z.domainImporter.DescribeDomainConfigList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain config failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	oldConfigs := make(map[string]*repository.DomainConfig)
+	for _, v := range configs {
+		oldConfigs[*v.DomainName] = v
+	}
 	for _, v := range oldDomains {
 		newDomainName := z.getNewName(*v.DomainName)
 		req := teo.NewCreateAccelerationDomainRequest()
@@ -84,14 +137,36 @@ func (z *ZoneCopyManager) ImportDomains() error {
 			log.Printf("domain：%v -> %v convert config failed， err: %v\n", *v.DomainName, *req.DomainName, err)
 			return err
 		}
+		z.applyDomainOriginOverride(newDomainName, req.OriginInfo)
+		// 已存在的域名不重复创建，但仍按模板站点更新域名级配置
 		if err = z.domainImporter.CreateDomain(req); err != nil {
 			log.Printf("domain：%v -> %v import failed， err: %v\n", *v.DomainName, *req.DomainName, err)
 			return err
 		}
+		if err = z.importDomainConfig(oldConfigs[*v.DomainName], req); err != nil {
+			log.Printf("domain：%v -> %v import config failed， err: %v\n", *v.DomainName, *req.DomainName, err)
+			return err
+		}
 	}
 	return nil
 }
 
+// importDomainConfig 导入加速域名的回源协议、端口、回源HOST及IPv6等域名级配置。
+func (z *ZoneCopyManager) importDomainConfig(old *repository.DomainConfig, req *teo.CreateAccelerationDomainRequest) error {
+	if old == nil {
+		return nil
+	}
+	config := *old
+	config.DomainName = req.DomainName
+	if config.OriginDetail != nil && config.OriginDetail.HostHeader != nil {
+		config.OriginDetail = &repository.DomainOriginDetail{
+			HostHeader: common.StringPtr(z.convertOriginValue(*config.OriginDetail.HostHeader)),
+		}
+	}
+	z.applyDomainConfigOverride(*req.DomainName, &config)
+	return z.domainImporter.ModifyDomainConfig(*req.ZoneId, &config, req.OriginInfo)
+}
+
 // converDomainOrigin 域名导入时调整源站信息。
 func (z *ZoneCopyManager) converDomainOrigin(old *teo.OriginDetail) (*teo.OriginInfo, error) {
 	nw := &teo.OriginInfo{}
@@ -101,6 +176,18 @@ func (z *ZoneCopyManager) converDomainOrigin(old *teo.OriginDetail) (*teo.Origin
 	nw.PrivateAccess = old.PrivateAccess
 	nw.PrivateParameters = old.PrivateParameters
 
+	if *nw.OriginType == "IP_DOMAIN" && nw.Origin != nil {
+		nw.Origin = common.StringPtr(z.convertOriginValue(*nw.Origin))
+	}
+	// 负载均衡的话需替换实例Id
+	if *nw.OriginType == "LB" {
+		id, err := z.getNewLBId(*nw.Origin)
+		if err != nil {
+			return nil, err
+		}
+		nw.Origin = common.StringPtr(id)
+		return nw, nil
+	}
 	// 源站组的话需替换OriginGroupId
 	if *nw.OriginType != "ORIGIN_GROUP" {
 		return nw, nil
@@ -122,26 +209,42 @@ func (z *ZoneCopyManager) converDomainOrigin(old *teo.OriginDetail) (*teo.Origin
 	return nw, nil
 }
 
+// initOriginMap 初始化新旧站点源站组的映射关系。
+func (z *ZoneCopyManager) initOriginMap() error {
+	if z.isOriginInit {
+		return nil
+	}
+	oldGroups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range oldGroups {
+		z.templateOrigin[*v.OriginGroupId] = *v.OriginGroupName
+	}
+	newGroups, err := z.originImporter.DescribeOriginGroupList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	for _, v := range newGroups {
+		z.targetOrigin[*v.OriginGroupName] = *v.OriginGroupId
+	}
+	z.isOriginInit = true
+	return nil
+}
+
+// resetOriginMap 清空源站组映射缓存，下次使用时重新初始化。
+func (z *ZoneCopyManager) resetOriginMap() {
+	z.isOriginInit = false
+	z.templateOrigin = make(map[string]string)
+	z.targetOrigin = make(map[string]string)
+}
+
 // getNewGroupId 旧站点GroupId转换为新站点GroupId。
 func (z *ZoneCopyManager) getNewGroupId(old string) (string, error) {
-	if !z.isOriginInit {
-		oldGroups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
-		if err != nil {
-			log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
-			return "", err
-		}
-		for _, v := range oldGroups {
-			z.templateOrigin[*v.OriginGroupId] = *v.OriginGroupName
-		}
-		newGroups, err := z.originImporter.DescribeOriginGroupList(z.config.TargetZoneId)
-		if err != nil {
-			log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TargetZoneId, err)
-			return "", err
-		}
-		for _, v := range newGroups {
-			z.targetOrigin[*v.OriginGroupName] = *v.OriginGroupId
-		}
-		z.isOriginInit = true
+	if err := z.initOriginMap(); err != nil {
+		return "", err
 	}
 	name, ok := z.templateOrigin[old]
 	if !ok {
@@ -185,6 +288,10 @@ func (z *ZoneCopyManager) ImportRuleEngineRules() error {
 			return err
 		}
 		req.Tags = v.Tags
+		if err = z.validateRuleRequest(req); err != nil {
+			log.Printf("rule name: %v validate failed, err: %v\n", *req.RuleName, err)
+			return err
+		}
 		if err = z.ruleImporter.CreateRule(req); err != nil {
 			log.Printf("rule name: %v import failed, err: %v\n", *req.RuleName, err)
 			return err
@@ -199,13 +306,20 @@ func (z *ZoneCopyManager) convertRules(old []*teo.Rule) ([]*teo.Rule, error) {
 	if len(old) != 1 {
 		return nil, fmt.Errorf("rule abnormal format")
 	}
-	// 第一层的if中condition需要转换域名相关条件，action暂未发现需要转换的
+	// 第一层的if中condition需要转换域名相关条件，action仅需转换内容标识符Id
 	// 第二层的if中condition需要转换域名相关条件，action需转换源站GroupId
 
 	// 第一层if的替换
 	if err := z.convertConditions(old[0].Conditions); err != nil {
 		return nil, err
 	}
+	for _, v := range old[0].Actions {
+		// 替换参数值中的变量占位符
+		z.resolveActionVars(v)
+	}
+	if err := z.convertContentIds(old[0].Actions); err != nil {
+		return nil, err
+	}
 	// 第二层if的判断
 	subRule := old[0].SubRules
 	if len(subRule) == 0 {
@@ -228,6 +342,12 @@ func (z *ZoneCopyManager) convertRules(old []*teo.Rule) ([]*teo.Rule, error) {
 func (z *ZoneCopyManager) convertConditions(conds []*teo.RuleAndConditions) error {
 	for _, v1 := range conds {
 		for _, v2 := range v1.Conditions {
+			// 替换条件值中的变量占位符
+			for k := range v2.Values {
+				if v2.Values[k] != nil {
+					v2.Values[k] = common.StringPtr(z.resolveVars(*v2.Values[k]))
+				}
+			}
 			// 第一层if中condition的域名进行替换
 			if *v2.Target == "host" {
 				for k, _ := range v2.Values {
@@ -235,18 +355,67 @@ func (z *ZoneCopyManager) convertConditions(conds []*teo.RuleAndConditions) erro
 					v2.Values[k] = common.StringPtr(newDomainName)
 				}
 			}
+			// 客户端IP条件中引用的IP组Id替换为新站点IP组Id
+			for k := range v2.Values {
+				if !isIPGroupRef(v2, v2.Values[k]) {
+					continue
+				}
+				nw, err := z.getNewIPGroupValue(*v2.Values[k])
+				if err != nil {
+					return err
+				}
+				v2.Values[k] = common.StringPtr(nw)
+			}
+		}
+	}
+	return nil
+}
+
+// convertContentIds 替换参数值中引用的内容标识符Id。
+func (z *ZoneCopyManager) convertContentIds(actions []*teo.Action) error {
+	for i := range actions {
+		if actions[i].NormalAction == nil {
+			continue
+		}
+		for _, v := range actions[i].NormalAction.Parameters {
+			for k := range v.Values {
+				if v.Values[k] == nil || !strings.HasPrefix(*v.Values[k], "eocontent-") {
+					continue
+				}
+				nw, err := z.getNewContentId(*v.Values[k])
+				if err != nil {
+					return err
+				}
+				v.Values[k] = common.StringPtr(nw)
+			}
 		}
 	}
 	return nil
 }
 
 func (z *ZoneCopyManager) convertActions(actions []*teo.Action) error {
+	for _, v := range actions {
+		// 替换参数值中的变量占位符
+		z.resolveActionVars(v)
+	}
+	if err := z.convertContentIds(actions); err != nil {
+		return err
+	}
 	for i, _ := range actions {
 		// 修改源站GroupId
 		if actions[i].NormalAction != nil && *(actions[i].NormalAction.Action) == "Origin" {
 			for _, v := range actions[i].NormalAction.Parameters {
 				if *v.Name == "OriginGroupId" {
 					oldGroupId := v.Values[0]
+					// 回源到负载均衡实例时需替换实例Id
+					if strings.HasPrefix(*oldGroupId, "lb-") {
+						nw, err := z.getNewLBId(*oldGroupId)
+						if err != nil {
+							return err
+						}
+						v.Values[0] = common.StringPtr(nw)
+						continue
+					}
 					nw, err := z.getNewGroupId(*oldGroupId)
 					if err != nil {
 						return err
@@ -259,38 +428,75 @@ func (z *ZoneCopyManager) convertActions(actions []*teo.Action) error {
 	return nil
 }
 
-// ImportZoneSetting 导入全局站点配置。
-func (z *ZoneCopyManager) ImportZoneSetting() error {
-	sets, err := z.zoneSettingImporter.DescribeZoneSetting(z.config.TemplateZoneId)
+// zoneSettingMeta 站点配置中的站点信息字段，不属于加速配置，无需拷贝。
+var zoneSettingMeta = map[string]bool{
+	"ZoneName": true,
+	"Area":     true,
+}
+
+// ImportZoneSetting 导入全局站点配置，按接口原始结构拷贝模板站点的全部配置项，
+// 包括当前SDK版本未定义的配置项，返回目标站点接口不接受的配置项。
+func (z *ZoneCopyManager) ImportZoneSetting() ([]string, error) {
+	sets, err := z.zoneSettingImporter.DescribeZoneSettingRaw(z.config.TemplateZoneId)
 	if err != nil {
 		log.Printf("zone id: %v describe zone setting failed, err: %v\n", z.config.TemplateZoneId, err)
-		return err
+		return nil, err
 	}
-	req := teo.NewModifyZoneSettingRequest()
-	req.ZoneId = common.StringPtr(z.config.TargetZoneId)
-	req.CacheConfig = sets.CacheConfig
-	req.CacheKey = sets.CacheKey
-	req.MaxAge = sets.MaxAge
-	req.OfflineCache = sets.OfflineCache
-	req.Quic = sets.Quic
-	req.PostMaxSize = sets.PostMaxSize
-	req.Compression = sets.Compression
-	req.UpstreamHttp2 = sets.UpstreamHttp2
-	req.ForceRedirect = sets.ForceRedirect
-	req.Https = sets.Https
-	req.Origin = sets.Origin
-	req.SmartRouting = sets.SmartRouting
-	req.WebSocket = sets.WebSocket
-	req.ClientIpHeader = sets.ClientIpHeader
-	req.CachePrefresh = sets.CachePrefresh
-	req.Ipv6 = sets.Ipv6
-	req.ClientIpCountry = sets.ClientIpCountry
-	req.Grpc = sets.Grpc
-	// TODO: 媒体处理的配置当前版本接口不支持，无法拷贝
-	// req.ImageOptimize = sets.ImageOptimize
-	if err = z.zoneSettingImporter.ModifyZoneSetting(req); err != nil {
-		log.Printf("zone id: %v import zone setting failed, err: %v\n", *req.ZoneId, err)
-		return err
+	return z.modifyZoneSetting(zoneSettingSections(sets))
+}
+
+// modifyZoneSetting 修改目标站点配置，接口不识别的配置项从请求中移除后重试，返回被移除的配置项。
+func (z *ZoneCopyManager) modifyZoneSetting(sections map[string]json.RawMessage) ([]string, error) {
+	var skipped []string
+	for len(sections) > 0 {
+		err := z.zoneSettingImporter.ModifyZoneSettingRaw(z.config.TargetZoneId, sections)
+		if err == nil {
+			break
+		}
+		name := unknownSection(err, sections)
+		if name == "" {
+			log.Printf("zone id: %v import zone setting failed, err: %v\n", z.config.TargetZoneId, err)
+			return skipped, err
+		}
+		log.Printf("zone id: %v zone setting %v can not be copied\n", z.config.TargetZoneId, name)
+		delete(sections, name)
+		skipped = append(skipped, name)
 	}
-	return nil
+	return skipped, nil
+}
+
+// zoneSettingSections 提取站点配置中需要拷贝的配置项，忽略站点信息字段及未配置的项。
+func zoneSettingSections(sets map[string]json.RawMessage) map[string]json.RawMessage {
+	sections := make(map[string]json.RawMessage, len(sets))
+	for k, v := range sets {
+		if zoneSettingMeta[k] || len(v) == 0 || string(v) == "null" {
+			continue
+		}
+		sections[k] = v
+	}
+	return sections
+}
+
+// unknownSection 接口返回未知参数错误时，返回错误信息中提及的配置项名称。
+func unknownSection(err error, sections map[string]json.RawMessage) string {
+	msg := err.Error()
+	if !strings.Contains(msg, "UnknownParameter") {
+		return ""
+	}
+	// 配置项名称可能互为前缀，取最长的匹配
+	name := ""
+	for k := range sections {
+		if strings.Contains(msg, k) && len(k) > len(name) {
+			name = k
+		}
+	}
+	return name
+}
+
+// stringValue 返回字符串指针的值，nil时返回空字符串。
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
 }
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestUnknownSection(t *testing.T) {
+	sections := map[string]json.RawMessage{
+		"Origin":        nil,
+		"OfflineCache":  nil,
+		"ImageOptimize": nil,
+	}
+	cases := []struct {
+		msg, want string
+	}{
+		{"an API error has returned: [TencentCloudSDKError] Code=UnknownParameter, Message=The parameter `ImageOptimize` is not recognized", "ImageOptimize"},
+		{"an API error has returned: [TencentCloudSDKError] Code=InvalidParameter, Message=ImageOptimize is invalid", ""},
+		{"an API error has returned: [TencentCloudSDKError] Code=UnknownParameter, Message=The parameter `Foo` is not recognized", ""},
+	}
+	for _, c := range cases {
+		if got := unknownSection(errors.New(c.msg), sections); got != c.want {
+			t.Errorf("unknownSection(%q) = %q, want %q", c.msg, got, c.want)
+		}
+	}
+}
+
+func TestZoneSettingSections(t *testing.T) {
+	sets := map[string]json.RawMessage{
+		"ZoneName":      json.RawMessage(`"a.com"`),
+		"Area":          json.RawMessage(`"global"`),
+		"Quic":          json.RawMessage(`{"Switch":"on"}`),
+		"ImageOptimize": json.RawMessage(`null`),
+	}
+	got := zoneSettingSections(sets)
+	if len(got) != 1 || string(got["Quic"]) != `{"Switch":"on"}` {
+		t.Errorf("zoneSettingSections() = %v, want only Quic", got)
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/repository"
+)
+
+// dnsRecordTypes 支持拷贝的DNS记录类型。
+var dnsRecordTypes = map[string]bool{
+	"A":     true,
+	"AAAA":  true,
+	"CNAME": true,
+	"TXT":   true,
+	"MX":    true,
+}
+
+// hostPattern 记录内容中可能为域名的片段。
+var hostPattern = regexp.MustCompile(`[A-Za-z0-9_.-]+`)
+
+// ImportDnsRecords NS接入站点的DNS记录导入，模板站点或目标站点不是NS接入时不导入，返回跳过的原因。
+func (z *ZoneCopyManager) ImportDnsRecords() ([]string, error) {
+	for _, zoneId := range []string{z.config.TemplateZoneId, z.config.TargetZoneId} {
+		zone, err := z.zoneImporter.DescribeZone(zoneId)
+		if err != nil {
+			log.Printf("zone id: %v describe zone failed, err: %v\n", zoneId, err)
+			return nil, err
+		}
+		if zone == nil || zone.Type == nil || *zone.Type != "full" {
+			log.Printf("zone id: %v is not full access mode, skip dns record import\n", zoneId)
+			return []string{fmt.Sprintf("zone %v is not in full (NS) access mode, dns records not copied", zoneId)}, nil
+		}
+	}
+	oldRecords, err := z.dnsRecordImporter.DescribeDnsRecordList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe dns record list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	// 加速域名对应的记录由域名导入时生成，不做处理
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	proxied := make(map[string]bool)
+	for _, v := range domains {
+		proxied[*v.DomainName] = true
+	}
+	for _, v := range oldRecords {
+		if !dnsRecordTypes[*v.Type] || proxied[*v.Name] {
+			continue
+		}
+		record := &repository.DnsRecord{
+			ZoneId:   common.StringPtr(z.config.TargetZoneId),
+			Name:     common.StringPtr(z.getNewRecordName(*v.Name)),
+			Type:     v.Type,
+			Content:  v.Content,
+			Location: v.Location,
+			TTL:      v.TTL,
+			Weight:   v.Weight,
+			Priority: v.Priority,
+		}
+		switch *v.Type {
+		case "CNAME", "MX":
+			record.Content = common.StringPtr(z.getNewRecordName(*v.Content))
+		case "TXT":
+			// SPF include、校验值等内容中可能包含模板站点域名
+			record.Content = common.StringPtr(z.replaceRecordNames(*v.Content))
+		}
+		if err = z.dnsRecordImporter.CreateDnsRecord(record); err != nil {
+			log.Printf("dns record：%v %v -> %v import failed， err: %v\n", *v.Type, *v.Name, *record.Name, err)
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// getNewRecordName 按站点根域名转换记录名，非模板站点下的记录名保持不变。
+func (z *ZoneCopyManager) getNewRecordName(old string) string {
+	name := strings.TrimSuffix(old, ".")
+	switch {
+	case name == z.config.TemplateZone:
+		name = z.config.TargetZone
+	case strings.HasSuffix(name, "."+z.config.TemplateZone):
+		name = strings.TrimSuffix(name, z.config.TemplateZone) + z.config.TargetZone
+	default:
+		return old
+	}
+	if strings.HasSuffix(old, ".") {
+		name += "."
+	}
+	return name
+}
+
+// replaceRecordNames 替换记录内容中属于模板站点的域名，其余内容保持不变。
+func (z *ZoneCopyManager) replaceRecordNames(content string) string {
+	return hostPattern.ReplaceAllStringFunc(content, z.getNewRecordName)
+}
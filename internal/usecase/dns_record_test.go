@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"testing"
+
+	"zonecopy/internal/domain/entity"
+)
+
+func newTestManager() *ZoneCopyManager {
+	return &ZoneCopyManager{
+		config: &entity.ZoneCopyConfig{
+			TemplateZone: "zjd.asia",
+			TargetZone:   "example.com",
+		},
+	}
+}
+
+func TestGetNewRecordName(t *testing.T) {
+	z := newTestManager()
+	cases := []struct {
+		old, want string
+	}{
+		{"zjd.asia", "example.com"},
+		{"zjd.asia.", "example.com."},
+		{"www.zjd.asia", "www.example.com"},
+		{"mail.zjd.asia.", "mail.example.com."},
+		{"zjd.asia.cdn.com", "zjd.asia.cdn.com"},
+		{"xzjd.asia", "xzjd.asia"},
+		{"other.com", "other.com"},
+	}
+	for _, c := range cases {
+		if got := z.getNewRecordName(c.old); got != c.want {
+			t.Errorf("getNewRecordName(%q) = %q, want %q", c.old, got, c.want)
+		}
+	}
+}
+
+func TestReplaceRecordNames(t *testing.T) {
+	z := newTestManager()
+	cases := []struct {
+		old, want string
+	}{
+		{"v=spf1 include:_spf.zjd.asia ~all", "v=spf1 include:_spf.example.com ~all"},
+		{"google-site-verification=abc123", "google-site-verification=abc123"},
+		{"host=zjd.asia.cdn.com", "host=zjd.asia.cdn.com"},
+		{"a=zjd.asia,b=mail.zjd.asia.", "a=example.com,b=mail.example.com."},
+	}
+	for _, c := range cases {
+		if got := z.replaceRecordNames(c.old); got != c.want {
+			t.Errorf("replaceRecordNames(%q) = %q, want %q", c.old, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDomainPollInterval = 30 // 秒
+	defaultDomainTimeout      = 60 // 分钟
+	defaultRecordTTL          = 600
+)
+
+// DomainVerification 目标站点加速域名的CNAME及归属权校验信息。
+type DomainVerification struct {
+	DomainName           string `json:"domain_name"`
+	Cname                string `json:"cname"`
+	DomainStatus         string `json:"domain_status"`
+	IdentificationStatus string `json:"identification_status,omitempty"`
+	VerifyName           string `json:"verify_name,omitempty"` // 校验记录的完整主机名
+	VerifyType           string `json:"verify_type,omitempty"`
+	VerifyValue          string `json:"verify_value,omitempty"`
+}
+
+// VerifyDomains 收集CNAME接入目标站点中由模板站点复制的加速域名的CNAME及归属权校验记录，通过notify输出；
+// 配置domain_verify时写入JSON及zone文件报告，并轮询等待域名生效，返回超时仍未生效的域名。
+func (z *ZoneCopyManager) VerifyDomains(notify func(list []*DomainVerification)) ([]*DomainVerification, error) {
+	zone, err := z.zoneImporter.DescribeZone(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	if zone == nil || *zone.Type != "partial" {
+		return nil, nil
+	}
+	list, err := z.collectDomainVerifications()
+	if err != nil {
+		return nil, err
+	}
+	notify(list)
+	cfg := z.config.DomainVerify
+	if cfg == nil {
+		return nil, nil
+	}
+	if err = z.writeDomainVerificationReport(cfg.OutputDir, list); err != nil {
+		log.Printf("write domain verification report failed, err: %v\n", err)
+		return nil, err
+	}
+	return z.waitDomainsOnline(list)
+}
+
+func (z *ZoneCopyManager) collectDomainVerifications() ([]*DomainVerification, error) {
+	oldDomains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	copied := make(map[string]bool)
+	for _, v := range oldDomains {
+		copied[z.getNewName(*v.DomainName)] = true
+	}
+	newDomains, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	var list []*DomainVerification
+	for _, v := range newDomains {
+		if !copied[*v.DomainName] {
+			continue
+		}
+		d := &DomainVerification{
+			DomainName:           *v.DomainName,
+			Cname:                stringValue(v.Cname),
+			DomainStatus:         stringValue(v.DomainStatus),
+			IdentificationStatus: stringValue(v.IdentificationStatus),
+		}
+		if d.IdentificationStatus == "pending" {
+			record, err := z.zoneImporter.IdentifyDomain(z.config.TargetZone, d.DomainName)
+			if err != nil {
+				log.Printf("domain: %v identify failed, err: %v\n", d.DomainName, err)
+				return nil, err
+			}
+			if record != nil {
+				d.VerifyName = z.verifyRecordName(stringValue(record.Subdomain))
+				d.VerifyType = stringValue(record.RecordType)
+				d.VerifyValue = stringValue(record.RecordValue)
+			}
+		}
+		list = append(list, d)
+	}
+	return list, nil
+}
+
+// verifyRecordName 校验记录的主机记录相对于目标站点，转换为完整主机名。
+func (z *ZoneCopyManager) verifyRecordName(sub string) string {
+	if sub == "" || sub == "@" {
+		return z.config.TargetZone
+	}
+	if sub == z.config.TargetZone || strings.HasSuffix(sub, "."+z.config.TargetZone) {
+		return sub
+	}
+	return sub + "." + z.config.TargetZone
+}
+
+// writeDomainVerificationReport 输出 <目标站点>.verify.json 及 <目标站点>.verify.zone 两份报告。
+func (z *ZoneCopyManager) writeDomainVerificationReport(dir string, list []*DomainVerification) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	body, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	name := filepath.Join(dir, z.config.TargetZone+".verify")
+	if err = os.WriteFile(name+".json", body, 0644); err != nil {
+		return err
+	}
+	var b strings.Builder
+	for _, v := range list {
+		if v.VerifyName != "" {
+			b.WriteString(zoneFileLine(v.VerifyName, v.VerifyType, v.VerifyValue))
+		}
+		if v.Cname != "" {
+			b.WriteString(z.cnameZoneLine(v.DomainName, v.Cname))
+		}
+	}
+	return os.WriteFile(name+".zone", []byte(b.String()), 0644)
+}
+
+// zoneFileLine 生成一条zone文件记录，主机名为完整域名。
+func zoneFileLine(name, recordType, value string) string {
+	return fmt.Sprintf("%v.\t%v\tIN\t%v\t%v\n", name, defaultRecordTTL, recordType, zoneFileValue(recordType, value))
+}
+
+// zoneFileValue 按zone文件格式输出记录值，TXT记录需加引号，域名类记录需以点结尾。
+func zoneFileValue(recordType, value string) string {
+	switch recordType {
+	case "TXT":
+		return fmt.Sprintf("%q", value)
+	case "CNAME", "NS", "MX":
+		return strings.TrimSuffix(value, ".") + "."
+	}
+	return value
+}
+
+// waitDomainsOnline 轮询目标站点域名状态直到全部生效或超时，返回仍未生效的域名。
+func (z *ZoneCopyManager) waitDomainsOnline(list []*DomainVerification) ([]*DomainVerification, error) {
+	cfg := z.config.DomainVerify
+	interval, timeout := defaultDomainPollInterval, defaultDomainTimeout
+	if cfg.PollInterval > 0 {
+		interval = cfg.PollInterval
+	}
+	if cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+	deadline := time.Now().Add(time.Duration(timeout) * time.Minute)
+	for {
+		domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+		if err != nil {
+			log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+			return nil, err
+		}
+		status := make(map[string]string)
+		for _, v := range domains {
+			status[*v.DomainName] = stringValue(v.DomainStatus)
+		}
+		var pending []*DomainVerification
+		for _, v := range list {
+			v.DomainStatus = status[v.DomainName]
+			if v.DomainStatus != "online" {
+				pending = append(pending, v)
+			}
+		}
+		if len(pending) == 0 || time.Now().After(deadline) {
+			return pending, nil
+		}
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
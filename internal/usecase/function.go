@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/repository"
+)
+
+// ImportFunctions 边缘函数及其触发规则导入。
+func (z *ZoneCopyManager) ImportFunctions() error {
+	oldFunctions, err := z.functionImporter.DescribeFunctionList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe function list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	// 旧的FunctionId -> 新的FunctionId
+	functionIds := make(map[string]string)
+	for _, v := range oldFunctions {
+		id, err := z.functionImporter.CreateFunction(z.config.TargetZoneId, v)
+		if err != nil {
+			log.Printf("function：%v import failed, err: %v\n", *v.Name, err)
+			return err
+		}
+		functionIds[*v.FunctionId] = id
+	}
+
+	oldRules, err := z.functionImporter.DescribeFunctionRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe function rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	// 新建规则优先级最低，按原有优先级顺序导入
+	for _, v := range oldRules {
+		rule, err := z.convertFunctionRule(v, functionIds)
+		if err != nil {
+			return err
+		}
+		if err = z.functionImporter.CreateFunctionRule(z.config.TargetZoneId, rule); err != nil {
+			log.Printf("function rule：%v of function %v import failed, err: %v\n", stringValue(v.RuleId), stringValue(v.FunctionName), err)
+			return err
+		}
+	}
+	return nil
+}
+
+// convertFunctionRule 将模板站点的触发规则转换为目标站点的触发规则，替换其中的FunctionId及域名。
+func (z *ZoneCopyManager) convertFunctionRule(v *repository.FunctionRule, functionIds map[string]string) (*repository.FunctionRule, error) {
+	rule := &repository.FunctionRule{
+		TriggerType: v.TriggerType,
+		Remark:      v.Remark,
+	}
+	// 按地区触发的规则没有顶层FunctionId，执行的函数在RegionMappingSelections中
+	if t := stringValue(v.TriggerType); t == "" || t == "direct" {
+		id, ok := functionIds[stringValue(v.FunctionId)]
+		if !ok {
+			log.Printf("zoneId: %v not find new function id: %v", z.config.TargetZoneId, stringValue(v.FunctionId))
+			return nil, fmt.Errorf("not find new function id")
+		}
+		rule.FunctionId = common.StringPtr(id)
+	}
+	for _, c := range v.FunctionRuleConditions {
+		z.convertHostConditions(c.RuleConditions)
+	}
+	rule.FunctionRuleConditions = v.FunctionRuleConditions
+	// 按地区执行的函数同样需要替换为新站点的FunctionId
+	for _, r := range v.RegionMappingSelections {
+		nw, ok := functionIds[stringValue(r.FunctionId)]
+		if !ok {
+			log.Printf("zoneId: %v not find new function id: %v", z.config.TargetZoneId, stringValue(r.FunctionId))
+			return nil, fmt.Errorf("not find new function id")
+		}
+		rule.RegionMappingSelections = append(rule.RegionMappingSelections, &repository.RegionMappingSelection{
+			FunctionId: common.StringPtr(nw),
+			Regions:    r.Regions,
+		})
+	}
+	return rule, nil
+}
+
+// convertHostConditions 替换条件中的域名。
+func (z *ZoneCopyManager) convertHostConditions(conds []*teo.RuleCondition) {
+	for _, v := range conds {
+		if v.Target == nil || *v.Target != "host" {
+			continue
+		}
+		for k := range v.Values {
+			v.Values[k] = common.StringPtr(z.getNewName(*v.Values[k]))
+		}
+	}
+}
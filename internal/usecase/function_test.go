@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/repository"
+)
+
+func TestConvertFunctionRule(t *testing.T) {
+	z := newTestManager()
+	functionIds := map[string]string{"ef-old-1": "ef-new-1", "ef-old-2": "ef-new-2"}
+	cases := []struct {
+		name       string
+		rule       *repository.FunctionRule
+		wantId     string
+		wantRegion []string
+		wantErr    bool
+	}{
+		{
+			name:   "direct",
+			rule:   &repository.FunctionRule{TriggerType: common.StringPtr("direct"), FunctionId: common.StringPtr("ef-old-1")},
+			wantId: "ef-new-1",
+		},
+		{
+			name:   "trigger type empty",
+			rule:   &repository.FunctionRule{FunctionId: common.StringPtr("ef-old-2")},
+			wantId: "ef-new-2",
+		},
+		{
+			name: "region only",
+			rule: &repository.FunctionRule{
+				TriggerType: common.StringPtr("region"),
+				RegionMappingSelections: []*repository.RegionMappingSelection{
+					{FunctionId: common.StringPtr("ef-old-1"), Regions: common.StringPtrs([]string{"CN"})},
+					{FunctionId: common.StringPtr("ef-old-2"), Regions: common.StringPtrs([]string{"US"})},
+				},
+			},
+			wantRegion: []string{"ef-new-1", "ef-new-2"},
+		},
+		{
+			name:    "direct function missing",
+			rule:    &repository.FunctionRule{TriggerType: common.StringPtr("direct"), FunctionId: common.StringPtr("ef-unknown")},
+			wantErr: true,
+		},
+		{
+			name: "region function missing",
+			rule: &repository.FunctionRule{
+				TriggerType:             common.StringPtr("region"),
+				RegionMappingSelections: []*repository.RegionMappingSelection{{FunctionId: common.StringPtr("ef-unknown")}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, c := range cases {
+		got, err := z.convertFunctionRule(c.rule, functionIds)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%v: convertFunctionRule() err = %v, wantErr %v", c.name, err, c.wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if stringValue(got.FunctionId) != c.wantId {
+			t.Errorf("%v: FunctionId = %q, want %q", c.name, stringValue(got.FunctionId), c.wantId)
+		}
+		if len(got.RegionMappingSelections) != len(c.wantRegion) {
+			t.Errorf("%v: got %d region mappings, want %d", c.name, len(got.RegionMappingSelections), len(c.wantRegion))
+			continue
+		}
+		for i, r := range got.RegionMappingSelections {
+			if stringValue(r.FunctionId) != c.wantRegion[i] {
+				t.Errorf("%v: region mapping %d FunctionId = %q, want %q", c.name, i, stringValue(r.FunctionId), c.wantRegion[i])
+			}
+		}
+	}
+}
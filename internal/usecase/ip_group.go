@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// ipGroupPrefix 规则条件中引用IP组时参数值的前缀。
+const ipGroupPrefix = "ipgroup-"
+
+// ipGroupTargets 可引用IP组的规则条件匹配类型。
+var ipGroupTargets = map[string]bool{
+	"client_ip": true,
+}
+
+// isIPGroupRef 判断条件值是否为IP组引用，仅客户端IP条件中的值可引用IP组。
+func isIPGroupRef(cond *teo.RuleCondition, value *string) bool {
+	if cond.Target == nil || !ipGroupTargets[*cond.Target] || value == nil {
+		return false
+	}
+	return strings.HasPrefix(*value, ipGroupPrefix)
+}
+
+// ImportIPGroups IP组导入。
+func (z *ZoneCopyManager) ImportIPGroups() error {
+	oldGroups, err := z.ipGroupImporter.DescribeIPGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe ip group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range oldGroups {
+		id, err := z.ipGroupImporter.CreateIPGroup(z.config.TargetZoneId, v)
+		if err != nil {
+			log.Printf("ip group：%v import failed, err: %v\n", *v.Name, err)
+			return err
+		}
+		z.templateIPGroup[*v.GroupId] = *v.Name
+		z.targetIPGroup[*v.Name] = id
+	}
+	return nil
+}
+
+// initIPGroupMap 初始化新旧站点IP组的映射关系。
+func (z *ZoneCopyManager) initIPGroupMap() error {
+	if z.isIPGroupInit {
+		return nil
+	}
+	oldGroups, err := z.ipGroupImporter.DescribeIPGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe ip group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range oldGroups {
+		z.templateIPGroup[*v.GroupId] = *v.Name
+	}
+	newGroups, err := z.ipGroupImporter.DescribeIPGroupList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe ip group failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	for _, v := range newGroups {
+		z.targetIPGroup[*v.Name] = *v.GroupId
+	}
+	z.isIPGroupInit = true
+	return nil
+}
+
+// getNewIPGroupId 旧站点IP组Id转换为新站点IP组Id。
+func (z *ZoneCopyManager) getNewIPGroupId(old int64) (int64, error) {
+	if err := z.initIPGroupMap(); err != nil {
+		return 0, err
+	}
+	name, ok := z.templateIPGroup[old]
+	if !ok {
+		log.Printf("zoneId: %v not find old ip group name: %v", z.config.TargetZoneId, old)
+		return 0, fmt.Errorf("not find old ip group name")
+	}
+	id, ok := z.targetIPGroup[name]
+	if !ok {
+		log.Printf("zoneId: %v not find new ip group id: %v", z.config.TargetZoneId, old)
+		return 0, fmt.Errorf("not find new ip group id")
+	}
+	return id, nil
+}
+
+// getNewIPGroupValue 将规则条件中引用的旧站点IP组替换为新站点IP组。
+func (z *ZoneCopyManager) getNewIPGroupValue(old string) (string, error) {
+	old = strings.TrimPrefix(old, ipGroupPrefix)
+	id, err := strconv.ParseInt(old, 10, 64)
+	if err != nil {
+		log.Printf("zoneId: %v invalid ip group reference: %v", z.config.TemplateZoneId, old)
+		return "", err
+	}
+	nw, err := z.getNewIPGroupId(id)
+	if err != nil {
+		return "", err
+	}
+	return ipGroupPrefix + strconv.FormatInt(nw, 10), nil
+}
+
+// isTargetIPGroupId 判断IP组Id是否属于目标站点。
+func (z *ZoneCopyManager) isTargetIPGroupId(id int64) bool {
+	for _, v := range z.targetIPGroup {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
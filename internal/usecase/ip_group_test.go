@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+func newIPGroupTestManager() *ZoneCopyManager {
+	z := newTestManager()
+	z.isIPGroupInit = true
+	z.templateIPGroup = map[int64]string{101: "office"}
+	z.targetIPGroup = map[string]int64{"office": 201}
+	return z
+}
+
+func TestConvertConditionsIPGroup(t *testing.T) {
+	z := newIPGroupTestManager()
+	cases := []struct {
+		target, value, want string
+	}{
+		{"client_ip", "ipgroup-101", "ipgroup-201"},
+		{"client_ip", "10.0.0.0/8", "10.0.0.0/8"},
+		{"url", "ipgroup-foo", "ipgroup-foo"},
+		{"request_header", "ipgroup-101", "ipgroup-101"},
+	}
+	for _, c := range cases {
+		conds := []*teo.RuleAndConditions{{Conditions: []*teo.RuleCondition{{
+			Target:   common.StringPtr(c.target),
+			Operator: common.StringPtr("equal"),
+			Values:   common.StringPtrs([]string{c.value}),
+		}}}}
+		if err := z.convertConditions(conds); err != nil {
+			t.Errorf("convertConditions(%v %v) err: %v", c.target, c.value, err)
+			continue
+		}
+		if got := *conds[0].Conditions[0].Values[0]; got != c.want {
+			t.Errorf("convertConditions(%v %v) = %v, want %v", c.target, c.value, got, c.want)
+		}
+	}
+}
+
+func TestValidateIPGroupRef(t *testing.T) {
+	z := newIPGroupTestManager()
+	cases := []struct {
+		value, wantErr string
+	}{
+		{"ipgroup-201", ""},
+		{"ipgroup-101", "template ip group id"},
+		{"ipgroup-999", "unknown ip group id"},
+		{"ipgroup-foo", "invalid ip group reference"},
+	}
+	for _, c := range cases {
+		errs := z.validateIPGroupRef("Values[0]", c.value)
+		if c.wantErr == "" {
+			if len(errs) > 0 {
+				t.Errorf("validateIPGroupRef(%v) = %v, want no error", c.value, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 || !strings.Contains(errs[0], c.wantErr) {
+			t.Errorf("validateIPGroupRef(%v) = %v, want %q", c.value, errs, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/repository"
+)
+
+// ImportLoadBalancers 负载均衡实例导入，需在源站组导入之后执行。
+func (z *ZoneCopyManager) ImportLoadBalancers() error {
+	oldLbs, err := z.loadBalancerImporter.DescribeLoadBalancerList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe load balancer list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range oldLbs {
+		req := &repository.CreateLoadBalancerRequest{
+			ZoneId:         common.StringPtr(z.config.TargetZoneId),
+			Name:           v.Name,
+			Type:           v.Type,
+			HealthChecker:  v.HealthChecker,
+			SteeringPolicy: v.SteeringPolicy,
+			FailoverPolicy: v.FailoverPolicy,
+		}
+		for _, g := range v.OriginGroupHealthStatus {
+			id, err := z.getNewGroupId(*g.OriginGroupID)
+			if err != nil {
+				log.Printf("load balancer：%v convert origin group %v failed, err: %v\n", *v.Name, *g.OriginGroupID, err)
+				return err
+			}
+			req.OriginGroups = append(req.OriginGroups, &repository.OriginGroupInLoadBalancer{
+				Priority:      g.Priority,
+				OriginGroupId: common.StringPtr(id),
+			})
+		}
+		id, err := z.loadBalancerImporter.CreateLoadBalancer(req)
+		if err != nil {
+			log.Printf("load balancer：%v import failed, err: %v\n", *v.Name, err)
+			return err
+		}
+		if z.isLBInit {
+			z.templateLB[*v.InstanceId] = *v.Name
+			z.targetLB[*v.Name] = id
+		}
+	}
+	return nil
+}
+
+// initLBMap 初始化新旧站点负载均衡实例的映射关系。
+func (z *ZoneCopyManager) initLBMap() error {
+	if z.isLBInit {
+		return nil
+	}
+	oldLbs, err := z.loadBalancerImporter.DescribeLoadBalancerList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe load balancer list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range oldLbs {
+		z.templateLB[*v.InstanceId] = *v.Name
+	}
+	newLbs, err := z.loadBalancerImporter.DescribeLoadBalancerList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe load balancer list failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	for _, v := range newLbs {
+		z.targetLB[*v.Name] = *v.InstanceId
+	}
+	z.isLBInit = true
+	return nil
+}
+
+// getNewLBId 旧站点负载均衡实例Id转换为新站点实例Id。
+func (z *ZoneCopyManager) getNewLBId(old string) (string, error) {
+	if err := z.initLBMap(); err != nil {
+		return "", err
+	}
+	name, ok := z.templateLB[old]
+	if !ok {
+		log.Printf("zoneId: %v not find old load balancer name: %v", z.config.TargetZoneId, old)
+		return "", fmt.Errorf("not find old load balancer name")
+	}
+	id, ok := z.targetLB[name]
+	if !ok {
+		log.Printf("zoneId: %v not find new load balancer id: %v", z.config.TargetZoneId, old)
+		return "", fmt.Errorf("not find new load balancer id")
+	}
+	return id, nil
+}
+
+// isTargetLBId 判断负载均衡实例Id是否属于目标站点。
+func (z *ZoneCopyManager) isTargetLBId(id string) bool {
+	for _, v := range z.targetLB {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
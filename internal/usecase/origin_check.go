@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// convertOriginValue 替换源站记录或回源HOST中的变量占位符，并按配置将模板站点域名替换为目标站点域名。
+func (z *ZoneCopyManager) convertOriginValue(old string) string {
+	nw := z.resolveVars(old)
+	if !z.config.RewriteOrigin {
+		return nw
+	}
+	return z.getNewRecordName(nw)
+}
+
+// CheckOriginGroups 源站组导入前的预检，返回存在风险的源站记录说明。
+func (z *ZoneCopyManager) CheckOriginGroups() ([]string, error) {
+	oldGroups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	var risks []string
+	for _, g := range oldGroups {
+		v := z.buildOriginGroupRequest(g)
+		prefix := fmt.Sprintf("origin group %v", *v.OriginGroupName)
+		for _, r := range v.OriginRecords {
+			record := *r.Record
+			risks = append(risks, z.checkOriginHost(prefix+" record "+record, record)...)
+			if r.Private != nil && *r.Private && len(r.PrivateParameters) == 0 {
+				risks = append(risks, fmt.Sprintf("%v record %v: private access without authorization parameters", prefix, record))
+			}
+		}
+		if v.HostHeader != nil && *v.HostHeader != "" {
+			host := *v.HostHeader
+			if z.isTemplateHost(host) {
+				risks = append(risks, fmt.Sprintf("%v host header %v: still points at template zone", prefix, host))
+			}
+		}
+	}
+	return risks, nil
+}
+
+// CheckDomainOrigins 域名导入前的预检，返回存在风险的域名源站说明。
+func (z *ZoneCopyManager) CheckDomainOrigins() ([]string, error) {
+	oldDomains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	var risks []string
+	for _, v := range oldDomains {
+		if v.OriginDetail == nil || v.OriginDetail.OriginType == nil {
+			continue
+		}
+		o := &teo.OriginInfo{
+			OriginType:        v.OriginDetail.OriginType,
+			Origin:            v.OriginDetail.Origin,
+			PrivateAccess:     v.OriginDetail.PrivateAccess,
+			PrivateParameters: v.OriginDetail.PrivateParameters,
+		}
+		if *o.OriginType == "IP_DOMAIN" {
+			o.Origin = common.StringPtr(z.convertOriginValue(*o.Origin))
+		}
+		newDomainName := z.getNewName(*v.DomainName)
+		z.applyDomainOriginOverride(newDomainName, o)
+		prefix := fmt.Sprintf("domain %v origin", newDomainName)
+		switch *o.OriginType {
+		case "IP_DOMAIN":
+			origin := *o.Origin
+			risks = append(risks, z.checkOriginHost(prefix+" "+origin, origin)...)
+		case "COS":
+			if o.PrivateAccess != nil && *o.PrivateAccess == "on" && len(o.PrivateParameters) == 0 {
+				risks = append(risks, fmt.Sprintf("%v %v: private bucket without authorization parameters", prefix, *o.Origin))
+			}
+		}
+	}
+	return risks, nil
+}
+
+// checkOriginHost 检查源站地址是否仍指向模板站点，以及域名能否正常解析。
+func (z *ZoneCopyManager) checkOriginHost(desc, host string) []string {
+	if net.ParseIP(host) != nil {
+		return nil
+	}
+	var risks []string
+	if z.isTemplateHost(host) {
+		risks = append(risks, desc+": still points at template zone")
+	}
+	if _, err := net.LookupHost(host); err != nil {
+		risks = append(risks, fmt.Sprintf("%v: resolve failed, err: %v", desc, err))
+	}
+	return risks
+}
+
+// isTemplateHost 判断域名是否属于模板站点。
+func (z *ZoneCopyManager) isTemplateHost(host string) bool {
+	host = strings.TrimSuffix(host, ".")
+	return host == z.config.TemplateZone || strings.HasSuffix(host, "."+z.config.TemplateZone)
+}
+
+// convertOriginRecords 按配置转换源站组中的源站记录。
+func (z *ZoneCopyManager) convertOriginRecords(old []*teo.OriginRecord) []*teo.OriginRecord {
+	records := make([]*teo.OriginRecord, 0, len(old))
+	for _, v := range old {
+		r := *v
+		r.Record = common.StringPtr(z.convertOriginValue(*v.Record))
+		records = append(records, &r)
+	}
+	return records
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// ImportOriginProtection 源站防护导入，返回目标站点需在源站放行的回源IP段。
+func (z *ZoneCopyManager) ImportOriginProtection() (*teo.IPWhitelist, error) {
+	old, err := z.originProtectionImporter.DescribeOriginProtection(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin protection failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	if old.Status == nil || *old.Status != "online" {
+		log.Printf("zone id: %v origin protection is not enabled, skip\n", z.config.TemplateZoneId)
+		return nil, nil
+	}
+	nw, err := z.originProtectionImporter.DescribeOriginProtection(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin protection failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	if nw.PlanSupport != nil && !*nw.PlanSupport {
+		return nil, fmt.Errorf("target zone plan does not support origin protection")
+	}
+	exist := make(map[string]bool)
+	for _, v := range nw.Hosts {
+		exist[*v] = true
+	}
+	var hosts []string
+	for _, v := range old.Hosts {
+		host := z.getNewName(*v)
+		if !exist[host] {
+			hosts = append(hosts, host)
+		}
+	}
+	if len(hosts) > 0 {
+		if nw.Status != nil && *nw.Status == "online" {
+			err = z.originProtectionImporter.AddOriginACLHosts(z.config.TargetZoneId, hosts)
+		} else {
+			err = z.originProtectionImporter.EnableOriginACL(z.config.TargetZoneId, hosts)
+		}
+		if err != nil {
+			log.Printf("zone id: %v import origin protection failed, err: %v\n", z.config.TargetZoneId, err)
+			return nil, err
+		}
+		// 重新查询以获取开启后的回源IP段
+		if nw, err = z.originProtectionImporter.DescribeOriginProtection(z.config.TargetZoneId); err != nil {
+			log.Printf("zone id: %v describe origin protection failed, err: %v\n", z.config.TargetZoneId, err)
+			return nil, err
+		}
+	}
+	if nw.DiffIPWhitelist != nil && nw.DiffIPWhitelist.LatestIPWhitelist != nil {
+		return nw.DiffIPWhitelist.LatestIPWhitelist, nil
+	}
+	return nw.CurrentIPWhitelist, nil
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+	"zonecopy/internal/repository"
+)
+
+// buildOriginGroupRequest 根据模板站点源站组生成目标站点的创建请求。
+func (z *ZoneCopyManager) buildOriginGroupRequest(v *teo.OriginGroup) *teo.CreateOriginGroupRequest {
+	req := teo.NewCreateOriginGroupRequest()
+	req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+	req.OriginType = v.OriginType
+	req.OriginGroupName = v.OriginGroupName
+	req.ConfigurationType = v.ConfigurationType
+	req.OriginRecords = z.convertOriginRecords(v.OriginRecords)
+	req.HostHeader = v.HostHeader
+	if v.HostHeader != nil {
+		req.HostHeader = common.StringPtr(z.convertOriginValue(*v.HostHeader))
+	}
+	z.applyOriginGroupOverride(req)
+	return req
+}
+
+// applyOriginGroupOverride 使用目标站点配置中的覆盖配置替换源站组配置。
+func (z *ZoneCopyManager) applyOriginGroupOverride(req *teo.CreateOriginGroupRequest) {
+	if z.config.Overrides == nil {
+		return
+	}
+	o, ok := z.config.Overrides.OriginGroups[*req.OriginGroupName]
+	if !ok {
+		return
+	}
+	if o.HostHeader != "" {
+		req.HostHeader = common.StringPtr(o.HostHeader)
+	}
+	for _, r := range req.OriginRecords {
+		for _, ro := range o.Records {
+			// 按模板站点中的记录值匹配，记录值可能已按配置替换过域名
+			if ro.Match != *r.Record && z.convertOriginValue(ro.Match) != *r.Record {
+				continue
+			}
+			if ro.Record != "" {
+				r.Record = common.StringPtr(ro.Record)
+			}
+			if ro.Port != nil {
+				r.Port = ro.Port
+			}
+			if ro.Weight != nil {
+				r.Weight = ro.Weight
+			}
+			if ro.Private != nil {
+				r.Private = ro.Private
+			}
+			if len(ro.PrivateParameters) > 0 {
+				r.PrivateParameters = convertPrivateParameters(ro.PrivateParameters)
+			}
+			break
+		}
+	}
+}
+
+// applyDomainOriginOverride 使用目标站点配置中的覆盖配置替换域名源站信息。
+func (z *ZoneCopyManager) applyDomainOriginOverride(domainName string, info *teo.OriginInfo) {
+	o := z.getDomainOverride(domainName)
+	if o == nil {
+		return
+	}
+	if o.Origin != "" {
+		info.Origin = common.StringPtr(o.Origin)
+	}
+	if o.BackupOrigin != "" {
+		info.BackupOrigin = common.StringPtr(o.BackupOrigin)
+	}
+	if o.PrivateAccess != "" {
+		info.PrivateAccess = common.StringPtr(o.PrivateAccess)
+	}
+	if len(o.PrivateParameters) > 0 {
+		info.PrivateParameters = convertPrivateParameters(o.PrivateParameters)
+	}
+}
+
+// applyDomainConfigOverride 使用目标站点配置中的覆盖配置替换域名回源配置。
+func (z *ZoneCopyManager) applyDomainConfigOverride(domainName string, config *repository.DomainConfig) {
+	o := z.getDomainOverride(domainName)
+	if o == nil {
+		return
+	}
+	if o.HostHeader != "" {
+		config.OriginDetail = &repository.DomainOriginDetail{
+			HostHeader: common.StringPtr(o.HostHeader),
+		}
+	}
+	if o.HttpOriginPort != nil {
+		config.HttpOriginPort = o.HttpOriginPort
+	}
+	if o.HttpsOriginPort != nil {
+		config.HttpsOriginPort = o.HttpsOriginPort
+	}
+}
+
+func (z *ZoneCopyManager) getDomainOverride(domainName string) *entity.DomainOverride {
+	if z.config.Overrides == nil {
+		return nil
+	}
+	return z.config.Overrides.Domains[domainName]
+}
+
+func convertPrivateParameters(params []*entity.PrivateParameter) []*teo.PrivateParameter {
+	var nw []*teo.PrivateParameter
+	for _, v := range params {
+		nw = append(nw, &teo.PrivateParameter{
+			Name:  common.StringPtr(v.Name),
+			Value: common.StringPtr(v.Value),
+		})
+	}
+	return nw
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+)
+
+// 导入计划中对象的操作类型。
+const (
+	PlanCreate    = "create"    // 目标站点中不存在，将新建
+	PlanSkip      = "skip"      // 目标站点中已存在，跳过
+	PlanOverwrite = "overwrite" // 将覆盖目标站点中的现有配置
+)
+
+// PlanItem 导入计划中的一个对象。
+type PlanItem struct {
+	Object string
+	Action string
+}
+
+// PlanOriginGroups 源站组的导入计划，目标站点已存在同名源站组时跳过。
+func (z *ZoneCopyManager) PlanOriginGroups() ([]*PlanItem, error) {
+	groups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	current, err := z.originImporter.DescribeOriginGroupList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, v := range current {
+		existing[*v.OriginGroupName] = true
+	}
+	var items []*PlanItem
+	for _, v := range groups {
+		items = append(items, planItem(*v.OriginGroupName, existing[*v.OriginGroupName]))
+	}
+	return items, nil
+}
+
+// PlanDomains 加速域名的导入计划，目标站点已存在的域名将覆盖其域名级配置。
+func (z *ZoneCopyManager) PlanDomains() ([]*PlanItem, error) {
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	current, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, v := range current {
+		existing[*v.DomainName] = true
+	}
+	var items []*PlanItem
+	for _, v := range domains {
+		name := z.getNewName(*v.DomainName)
+		// 已存在的域名会按模板站点覆盖域名级配置
+		if existing[name] {
+			items = append(items, &PlanItem{Object: name, Action: PlanOverwrite})
+			continue
+		}
+		items = append(items, planItem(name, false))
+	}
+	return items, nil
+}
+
+// PlanRules 规则引擎的导入计划，目标站点已存在同名规则时跳过。
+func (z *ZoneCopyManager) PlanRules() ([]*PlanItem, error) {
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	current, err := z.ruleImporter.DescribeRuleList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, v := range current {
+		existing[*v.RuleName] = true
+	}
+	var items []*PlanItem
+	for _, v := range rules {
+		name := z.getNewName(*v.RuleName)
+		items = append(items, planItem(name, existing[name]))
+	}
+	return items, nil
+}
+
+// PlanZoneSetting 站点加速配置的导入计划，列出将被覆盖且与模板站点不一致的配置项。
+func (z *ZoneCopyManager) PlanZoneSetting() ([]*PlanItem, error) {
+	sets, err := z.zoneSettingImporter.DescribeZoneSettingRaw(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone setting failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	current, err := z.zoneSettingImporter.DescribeZoneSettingRaw(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone setting failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	var items []*PlanItem
+	// 与 ImportZoneSetting 提交相同的配置项，站点信息字段及空配置项不会被覆盖，不列入计划
+	for _, v := range zoneSettingDiff(current, zoneSettingSections(sets)) {
+		items = append(items, &PlanItem{Object: v, Action: PlanOverwrite})
+	}
+	if len(items) == 0 {
+		items = append(items, &PlanItem{Object: fmt.Sprintf("all settings of %v are unchanged", z.config.TargetZone), Action: PlanSkip})
+	}
+	return items, nil
+}
+
+func planItem(name string, exist bool) *PlanItem {
+	if exist {
+		return &PlanItem{Object: name, Action: PlanSkip}
+	}
+	return &PlanItem{Object: name, Action: PlanCreate}
+}
@@ -0,0 +1,216 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/repository"
+)
+
+// CheckCompatibility 导入前比较模板站点与目标站点的套餐、接入方式及加速区域，并按待导入的模块检查规则操作及域名配额，
+// 返回目标站点中可能无法使用的功能说明。预检只用于提示，查询失败时同样以提示返回，不阻断导入。
+func (z *ZoneCopyManager) CheckCompatibility(modules []string) []string {
+	var risks []string
+	skip := func(check string, err error) {
+		risks = append(risks, fmt.Sprintf("%v check skipped, err: %v", check, err))
+	}
+	selected := stringSet(modules)
+
+	tpl, err := z.zoneImporter.DescribeZone(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone failed, err: %v\n", z.config.TemplateZoneId, err)
+	}
+	target, err2 := z.zoneImporter.DescribeZone(z.config.TargetZoneId)
+	if err2 != nil {
+		log.Printf("zone id: %v describe zone failed, err: %v\n", z.config.TargetZoneId, err2)
+		err = err2
+	}
+	switch {
+	case err != nil:
+		skip("access mode", err)
+	case tpl == nil || target == nil:
+		skip("access mode", fmt.Errorf("zone not found"))
+	default:
+		if *tpl.Type != *target.Type {
+			risks = append(risks, fmt.Sprintf("access mode: template %v, target %v; dns records will not be copied and domains may need ownership verification", *tpl.Type, *target.Type))
+		}
+		if tpl.Area != nil && target.Area != nil && *tpl.Area != *target.Area {
+			risks = append(risks, fmt.Sprintf("acceleration area: template %v, target %v", *tpl.Area, *target.Area))
+		}
+	}
+
+	planRisks, newPlanType, err := z.checkPlan()
+	if err != nil {
+		skip("plan", err)
+	}
+	risks = append(risks, planRisks...)
+
+	if selected["rule"] {
+		ruleRisks, err := z.checkRuleActions(newPlanType)
+		if err != nil {
+			skip("rule action", err)
+		}
+		risks = append(risks, ruleRisks...)
+	}
+	if selected["domain"] {
+		quotaRisks, err := z.checkDomainQuota()
+		if err != nil {
+			skip("domain quota", err)
+		}
+		risks = append(risks, quotaRisks...)
+	}
+	return risks
+}
+
+// checkPlan 比较模板站点与目标站点的套餐类型及增值服务，套餐类型不同时返回目标站点的套餐类型。
+func (z *ZoneCopyManager) checkPlan() ([]string, string, error) {
+	oldPlanId, err := z.getPlanId(z.config.TemplateZoneId)
+	if err != nil {
+		return nil, "", err
+	}
+	newPlanId, err := z.getPlanId(z.config.TargetZoneId)
+	if err != nil {
+		return nil, "", err
+	}
+	if oldPlanId == newPlanId {
+		return nil, "", nil
+	}
+	oldPlan, err := z.planImporter.DescribePlan(oldPlanId)
+	if err != nil {
+		log.Printf("plan id: %v describe plan failed, err: %v\n", oldPlanId, err)
+		return nil, "", err
+	}
+	newPlan, err := z.planImporter.DescribePlan(newPlanId)
+	if err != nil {
+		log.Printf("plan id: %v describe plan failed, err: %v\n", newPlanId, err)
+		return nil, "", err
+	}
+	risks, newType := comparePlans(oldPlan, newPlan)
+	return risks, newType, nil
+}
+
+// comparePlans 比较两个套餐的类型、增值服务及加速区域，套餐类型不同或无法确定时返回目标套餐类型。
+func comparePlans(oldPlan, newPlan *repository.Plan) ([]string, string) {
+	var risks []string
+	oldType, newType := stringValue(oldPlan.PlanType), stringValue(newPlan.PlanType)
+	switch {
+	case oldType == "" || newType == "":
+		risks = append(risks, fmt.Sprintf("plan type: template %v, target %v; unknown plan type, check the target plan manually", planTypeName(oldType), planTypeName(newType)))
+		newType = planTypeName(newType)
+	case strings.TrimSuffix(oldType, "_with_bot") == strings.TrimSuffix(newType, "_with_bot"):
+		newType = ""
+	default:
+		// 接口未提供套餐间的功能对照，套餐类型不同即提示
+		risks = append(risks, fmt.Sprintf("plan type: template %v, target %v; features not included in the target plan will fail to import", oldType, newType))
+	}
+	if strings.HasSuffix(oldType, "_with_bot") && !strings.HasSuffix(stringValue(newPlan.PlanType), "_with_bot") {
+		risks = append(risks, "security add-on: target plan has no bot management")
+	}
+	if oldPlan.Area != nil && newPlan.Area != nil && *oldPlan.Area != *newPlan.Area {
+		risks = append(risks, fmt.Sprintf("plan area: template %v, target %v", *oldPlan.Area, *newPlan.Area))
+	}
+	return risks, newType
+}
+
+func planTypeName(t string) string {
+	if t == "" {
+		return "<unknown>"
+	}
+	return t
+}
+
+// checkRuleActions 检查模板站点规则中使用的操作在当前账号下是否可用。
+// 接口仅提供账号维度的可用操作，目标站点套餐类型不同时，列出模板站点规则使用的操作，需确认目标套餐是否包含。
+func (z *ZoneCopyManager) checkRuleActions(newPlanType string) ([]string, error) {
+	if err := z.initRuleActions(); err != nil {
+		return nil, err
+	}
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	var risks []string
+	used := make(map[string]bool)
+	var names []string
+	for _, r := range rules {
+		var actions []*teo.Action
+		for _, v := range r.Rules {
+			actions = append(actions, v.Actions...)
+			for _, sub := range v.SubRules {
+				for _, s := range sub.Rules {
+					actions = append(actions, s.Actions...)
+				}
+			}
+		}
+		for _, a := range actions {
+			name := actionName(a)
+			if name == "" {
+				continue
+			}
+			if !z.ruleActions[name] {
+				risks = append(risks, fmt.Sprintf("rule %v: action %v is not available to this account", *r.RuleName, name))
+			}
+			if !used[name] {
+				used[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	if newPlanType != "" && len(names) > 0 {
+		sort.Strings(names)
+		risks = append(risks, fmt.Sprintf("rule actions used by template: %v; make sure they are included in target plan %v", names, newPlanType))
+	}
+	return risks, nil
+}
+
+// checkDomainQuota 检查导入后目标站点的加速域名数量是否超出配置的 domain_quota。
+// 接口无法查询套餐的域名配额，未配置 domain_quota 时不检查，仅提示。
+func (z *ZoneCopyManager) checkDomainQuota() ([]string, error) {
+	if z.config.DomainQuota == 0 {
+		return []string{"domain quota: not checked, the plan quota can not be queried; set domain_quota to enable the check"}, nil
+	}
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	current, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	existing := make(map[string]bool, len(current))
+	for _, v := range current {
+		existing[*v.DomainName] = true
+	}
+	created := 0
+	for _, v := range domains {
+		if !existing[z.getNewName(*v.DomainName)] {
+			created++
+		}
+	}
+	if created == 0 {
+		return nil, nil
+	}
+	total := len(current) + created
+	if total > z.config.DomainQuota {
+		return []string{fmt.Sprintf("domain quota: %v domain(s) after import exceed domain_quota %v, the rest will fail to import", total, z.config.DomainQuota)}, nil
+	}
+	return nil, nil
+}
+
+func actionName(a *teo.Action) string {
+	switch {
+	case a.NormalAction != nil && a.NormalAction.Action != nil:
+		return *a.NormalAction.Action
+	case a.RewriteAction != nil && a.RewriteAction.Action != nil:
+		return *a.RewriteAction.Action
+	case a.CodeAction != nil && a.CodeAction.Action != nil:
+		return *a.CodeAction.Action
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/repository"
+)
+
+func TestComparePlans(t *testing.T) {
+	plan := func(planType, area string) *repository.Plan {
+		p := &repository.Plan{Area: common.StringPtr(area)}
+		if planType != "" {
+			p.PlanType = common.StringPtr(planType)
+		}
+		return p
+	}
+	cases := []struct {
+		name        string
+		old, nw     *repository.Plan
+		wantRisks   []string
+		wantNewType string
+	}{
+		{"same", plan("enterprise", "global"), plan("enterprise", "global"), nil, ""},
+		{"bot add-on missing", plan("standard_with_bot", "global"), plan("standard", "global"), []string{"security add-on"}, ""},
+		{"type differs", plan("enterprise", "global"), plan("basic", "global"), []string{"plan type"}, "basic"},
+		{"area differs", plan("basic", "mainland"), plan("basic", "global"), []string{"plan area"}, ""},
+		{"target type missing", plan("basic", "global"), plan("", "global"), []string{"unknown plan type"}, "<unknown>"},
+		{"template type missing", plan("", "global"), plan("basic", "global"), []string{"unknown plan type"}, "basic"},
+	}
+	for _, c := range cases {
+		risks, newType := comparePlans(c.old, c.nw)
+		if newType != c.wantNewType {
+			t.Errorf("%v: new plan type = %q, want %q", c.name, newType, c.wantNewType)
+		}
+		if len(risks) != len(c.wantRisks) {
+			t.Errorf("%v: risks = %v, want %v", c.name, risks, c.wantRisks)
+			continue
+		}
+		for i, v := range c.wantRisks {
+			if !strings.Contains(risks[i], v) {
+				t.Errorf("%v: risk %q does not contain %q", c.name, risks[i], v)
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"path"
+	"sort"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// ConfirmFunc 删除前的确认，kind为对象类型，names为待删除的对象名称，返回false时不删除。
+type ConfirmFunc func(kind string, names []string) bool
+
+// PruneOriginGroups 删除目标站点中模板站点不存在的源站组。
+func (z *ZoneCopyManager) PruneOriginGroups(confirm ConfirmFunc, notify func(msg string)) error {
+	groups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	keep := make(map[string]bool)
+	for _, v := range groups {
+		keep[*v.OriginGroupName] = true
+	}
+	return z.pruneOriginGroups(keep, confirm, notify)
+}
+
+// PruneDomains 删除目标站点中模板站点不存在的加速域名，模板站点域名按站点名替换后比较。
+func (z *ZoneCopyManager) PruneDomains(confirm ConfirmFunc, notify func(msg string)) error {
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	keep := make(map[string]bool)
+	for _, v := range domains {
+		keep[z.getNewName(*v.DomainName)] = true
+	}
+	return z.pruneDomains(keep, confirm, notify)
+}
+
+// PruneRules 删除目标站点中模板站点不存在的规则，规则名称与导入时一样按站点名替换后比较。
+func (z *ZoneCopyManager) PruneRules(confirm ConfirmFunc, notify func(msg string)) error {
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	keep := make(map[string]bool)
+	for _, v := range rules {
+		keep[z.getNewName(*v.RuleName)] = true
+	}
+	return z.pruneRules(keep, confirm, notify)
+}
+
+func (z *ZoneCopyManager) pruneRules(keep map[string]bool, confirm ConfirmFunc, notify func(msg string)) error {
+	current, err := z.ruleImporter.DescribeRuleList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	ids := make(map[string]string)
+	for _, v := range current {
+		if !keep[*v.RuleName] {
+			ids[*v.RuleName] = *v.RuleId
+		}
+	}
+	names := z.pruneCandidates(ids, notify)
+	if len(names) == 0 || !confirm("rule", names) {
+		return nil
+	}
+	for _, name := range names {
+		if err = z.ruleImporter.DeleteRules(z.config.TargetZoneId, []string{ids[name]}); err != nil {
+			log.Printf("rule: %v delete failed, err: %v\n", name, err)
+			return err
+		}
+		notify(fmt.Sprintf("rule %v deleted", name))
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) pruneDomains(keep map[string]bool, confirm ConfirmFunc, notify func(msg string)) error {
+	current, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	ids := make(map[string]string)
+	for _, v := range current {
+		if !keep[*v.DomainName] {
+			ids[*v.DomainName] = *v.DomainName
+		}
+	}
+	names := z.pruneCandidates(ids, notify)
+	if len(names) == 0 || !confirm("domain", names) {
+		return nil
+	}
+	for _, name := range names {
+		if err = z.domainImporter.DeleteDomains(z.config.TargetZoneId, []string{name}); err != nil {
+			log.Printf("domain: %v delete failed, err: %v\n", name, err)
+			return err
+		}
+		notify(fmt.Sprintf("domain %v deleted", name))
+	}
+	return nil
+}
+
+// pruneOriginGroups 删除源站组，仍被加速域名、规则或负载均衡实例引用的源站组跳过。
+func (z *ZoneCopyManager) pruneOriginGroups(keep map[string]bool, confirm ConfirmFunc, notify func(msg string)) error {
+	current, err := z.originImporter.DescribeOriginGroupList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	ids := make(map[string]string)
+	for _, v := range current {
+		if !keep[*v.OriginGroupName] {
+			ids[*v.OriginGroupName] = *v.OriginGroupId
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	used, err := z.usedOriginGroups()
+	if err != nil {
+		return err
+	}
+	for name, id := range ids {
+		if by, ok := used[id]; ok {
+			notify(fmt.Sprintf("origin group %v is still used by %v, skip deleting", name, by))
+			delete(ids, name)
+		}
+	}
+	names := z.pruneCandidates(ids, notify)
+	if len(names) == 0 || !confirm("origin group", names) {
+		return nil
+	}
+	for _, name := range names {
+		if err = z.originImporter.DeleteOrigin(z.config.TargetZoneId, ids[name]); err != nil {
+			log.Printf("origin group: %v delete failed, err: %v\n", name, err)
+			return err
+		}
+		notify(fmt.Sprintf("origin group %v deleted", name))
+	}
+	return nil
+}
+
+// usedOriginGroups 返回目标站点中被引用的源站组Id及引用方。
+func (z *ZoneCopyManager) usedOriginGroups() (map[string]string, error) {
+	used := make(map[string]string)
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	for _, v := range domains {
+		if v.OriginDetail == nil || v.OriginDetail.OriginType == nil || *v.OriginDetail.OriginType != "ORIGIN_GROUP" {
+			continue
+		}
+		for _, id := range []*string{v.OriginDetail.Origin, v.OriginDetail.BackupOrigin} {
+			if id != nil && *id != "" {
+				used[*id] = "domain " + *v.DomainName
+			}
+		}
+	}
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	for _, r := range rules {
+		var actions []*teo.Action
+		for _, v := range r.Rules {
+			actions = append(actions, v.Actions...)
+			for _, sub := range v.SubRules {
+				for _, s := range sub.Rules {
+					actions = append(actions, s.Actions...)
+				}
+			}
+		}
+		for _, a := range actions {
+			if a.NormalAction == nil || a.NormalAction.Action == nil || *a.NormalAction.Action != "Origin" {
+				continue
+			}
+			for _, p := range a.NormalAction.Parameters {
+				if p.Name == nil || *p.Name != "OriginGroupId" {
+					continue
+				}
+				for _, id := range p.Values {
+					if id != nil {
+						used[*id] = "rule " + *r.RuleName
+					}
+				}
+			}
+		}
+	}
+	lbs, err := z.loadBalancerImporter.DescribeLoadBalancerList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe load balancer failed, err: %v\n", z.config.TargetZoneId, err)
+		return nil, err
+	}
+	for _, v := range lbs {
+		for _, g := range v.OriginGroupHealthStatus {
+			if g.OriginGroupID != nil {
+				used[*g.OriginGroupID] = "load balancer " + *v.Name
+			}
+		}
+	}
+	return used, nil
+}
+
+// pruneCandidates 过滤受保护的对象，返回按名称排序的待删除对象。
+func (z *ZoneCopyManager) pruneCandidates(ids map[string]string, notify func(msg string)) []string {
+	var names []string
+	for name := range ids {
+		if z.isProtected(name) {
+			notify(fmt.Sprintf("%v is protected, skip deleting", name))
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isProtected 判断对象名称是否匹配配置中的受保护名称，支持通配符。
+func (z *ZoneCopyManager) isProtected(name string) bool {
+	for _, pattern := range z.config.PruneProtected {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
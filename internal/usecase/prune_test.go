@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"testing"
+
+	"zonecopy/internal/domain/entity"
+)
+
+func TestIsProtected(t *testing.T) {
+	z := &ZoneCopyManager{
+		config: &entity.ZoneCopyConfig{
+			PruneProtected: []string{"default-origin", "*.static.example.com", "rule-[ab]"},
+		},
+	}
+	cases := []struct {
+		name string
+		want bool
+	}{
+		{"default-origin", true},
+		{"default-origin-2", false},
+		{"img.static.example.com", true},
+		{"static.example.com", false},
+		{"a.b.static.example.com", true},
+		{"rule-a", true},
+		{"rule-c", false},
+		{"", false},
+	}
+	for _, c := range cases {
+		if got := z.isProtected(c.name); got != c.want {
+			t.Errorf("isProtected(%q) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
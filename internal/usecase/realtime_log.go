@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	"zonecopy/internal/repository"
+)
+
+// ImportRealtimeLogDeliveryTasks 实时日志投递任务导入。
+// 投递目标的密钥无法通过接口读取，相关任务跳过导入，返回跳过的任务及原因。
+func (z *ZoneCopyManager) ImportRealtimeLogDeliveryTasks() ([]string, error) {
+	oldTasks, err := z.realtimeLogImporter.DescribeRealtimeLogDeliveryTaskList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe realtime log delivery task failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	var skipped []string
+	for _, v := range oldTasks {
+		if reason := unsupportedLogTaskReason(v); reason != "" {
+			log.Printf("realtime log delivery task：%v skipped, %v\n", *v.TaskName, reason)
+			skipped = append(skipped, fmt.Sprintf("realtime log delivery task %v skipped: %v", *v.TaskName, reason))
+			continue
+		}
+		task := *v
+		task.EntityList = make([]*string, 0, len(v.EntityList))
+		for _, e := range v.EntityList {
+			task.EntityList = append(task.EntityList, common.StringPtr(z.getNewName(*e)))
+		}
+		if err = z.realtimeLogImporter.CreateRealtimeLogDeliveryTask(z.config.TargetZoneId, &task); err != nil {
+			log.Printf("realtime log delivery task：%v import failed, err: %v\n", *v.TaskName, err)
+			return skipped, err
+		}
+	}
+	return skipped, nil
+}
+
+// unsupportedLogTaskReason 返回任务无法自动拷贝的原因，可拷贝时返回空字符串。
+func unsupportedLogTaskReason(task *repository.RealtimeLogDeliveryTask) string {
+	// 四层代理的实体为代理实例Id，无法按域名进行映射
+	if task.LogType != nil && *task.LogType == "application" {
+		return "layer 4 proxy entities can't be mapped"
+	}
+	if task.CustomEndpoint != nil && isUnreadableSecret(task.CustomEndpoint.AccessId, task.CustomEndpoint.AccessKey) {
+		return "custom endpoint access key can't be read back"
+	}
+	if task.S3 != nil && isUnreadableSecret(task.S3.AccessId, task.S3.AccessKey) {
+		return "s3 access key can't be read back"
+	}
+	return ""
+}
+
+// isUnreadableSecret 配置了访问Id但密钥为空或被掩码时，认为密钥无法读取。
+func isUnreadableSecret(id, key *string) bool {
+	if id == nil || *id == "" {
+		return false
+	}
+	return key == nil || *key == "" || strings.Contains(*key, "*")
+}
@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// validateRuleRequest 规则转换后、调用接口前的本地校验，返回的错误中包含出错元素的完整路径。
+func (z *ZoneCopyManager) validateRuleRequest(req *teo.CreateRuleRequest) error {
+	if err := z.initRuleActions(); err != nil {
+		return err
+	}
+	if err := z.initOriginMap(); err != nil {
+		return err
+	}
+	var errs []string
+	if req.ZoneId == nil || *req.ZoneId != z.config.TargetZoneId {
+		errs = append(errs, "ZoneId: not target zone id")
+	}
+	for i, rule := range req.Rules {
+		path := fmt.Sprintf("Rules[%d]", i)
+		errs = append(errs, z.validateConditions(path, rule.Conditions)...)
+		errs = append(errs, z.validateActions(path, rule.Actions)...)
+		for j, sub := range rule.SubRules {
+			for k, v := range sub.Rules {
+				subPath := fmt.Sprintf("%v.SubRules[%d].Rules[%d]", path, j, k)
+				errs = append(errs, z.validateConditions(subPath, v.Conditions)...)
+				errs = append(errs, z.validateActions(subPath, v.Actions)...)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid rule: %v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// initRuleActions 获取规则引擎支持的全部操作名称。
+func (z *ZoneCopyManager) initRuleActions() error {
+	if z.ruleActions != nil {
+		return nil
+	}
+	actions, err := z.ruleImporter.DescribeRulesSetting()
+	if err != nil {
+		log.Printf("describe rules setting failed, err: %v\n", err)
+		return err
+	}
+	z.ruleActions = make(map[string]bool)
+	for _, v := range actions {
+		if v.Action != nil {
+			z.ruleActions[*v.Action] = true
+		}
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) validateConditions(path string, conds []*teo.RuleAndConditions) []string {
+	var errs []string
+	for i, v1 := range conds {
+		for j, v2 := range v1.Conditions {
+			condPath := fmt.Sprintf("%v.Conditions[%d].Conditions[%d]", path, i, j)
+			if v2.Target == nil || *v2.Target == "" {
+				errs = append(errs, condPath+".Target: empty")
+				continue
+			}
+			if len(v2.Values) == 0 && !allowEmptyValues(v2) {
+				errs = append(errs, condPath+".Values: empty")
+			}
+			for k, value := range v2.Values {
+				valuePath := fmt.Sprintf("%v.Values[%d]", condPath, k)
+				if value == nil || *value == "" {
+					errs = append(errs, valuePath+": empty")
+					continue
+				}
+				if *v2.Target == "host" && !z.isTargetHost(*value) {
+					errs = append(errs, fmt.Sprintf("%v: host %v not belong to zone %v", valuePath, *value, z.config.TargetZone))
+				}
+				if isIPGroupRef(v2, value) {
+					errs = append(errs, z.validateIPGroupRef(valuePath, *value)...)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// allowEmptyValues 仅查询字符串或HTTP请求头的存在/不存在判断允许空值。
+func allowEmptyValues(cond *teo.RuleCondition) bool {
+	if *cond.Target != "query_string" && *cond.Target != "request_header" {
+		return false
+	}
+	return cond.Operator != nil && (*cond.Operator == "exist" || *cond.Operator == "notexist")
+}
+
+// isTargetHost 判断域名是否属于目标站点。
+func (z *ZoneCopyManager) isTargetHost(host string) bool {
+	host = strings.TrimPrefix(host, "*.")
+	return host == z.config.TargetZone || strings.HasSuffix(host, "."+z.config.TargetZone)
+}
+
+func (z *ZoneCopyManager) validateActions(path string, actions []*teo.Action) []string {
+	var errs []string
+	for i, v := range actions {
+		actionPath := fmt.Sprintf("%v.Actions[%d]", path, i)
+		var name *string
+		switch {
+		case v.NormalAction != nil:
+			actionPath += ".NormalAction"
+			name = v.NormalAction.Action
+		case v.RewriteAction != nil:
+			actionPath += ".RewriteAction"
+			name = v.RewriteAction.Action
+		case v.CodeAction != nil:
+			actionPath += ".CodeAction"
+			name = v.CodeAction.Action
+		default:
+			errs = append(errs, actionPath+": empty action")
+			continue
+		}
+		if name == nil || !z.ruleActions[*name] {
+			errs = append(errs, fmt.Sprintf("%v.Action: unknown action %v", actionPath, stringValue(name)))
+			continue
+		}
+		if v.NormalAction == nil || *name != "Origin" {
+			continue
+		}
+		for j, p := range v.NormalAction.Parameters {
+			if p.Name == nil || *p.Name != "OriginGroupId" {
+				continue
+			}
+			for k, id := range p.Values {
+				paramPath := fmt.Sprintf("%v.Parameters[%d].Values[%d]", actionPath, j, k)
+				if id == nil || *id == "" {
+					errs = append(errs, paramPath+": empty")
+				} else if strings.HasPrefix(*id, "lb-") {
+					errs = append(errs, z.validateLBId(paramPath, *id)...)
+				} else if _, ok := z.templateOrigin[*id]; ok {
+					errs = append(errs, fmt.Sprintf("%v: template origin group id %v", paramPath, *id))
+				} else if !z.isTargetGroupId(*id) {
+					errs = append(errs, fmt.Sprintf("%v: unknown origin group id %v", paramPath, *id))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func (z *ZoneCopyManager) validateLBId(path, id string) []string {
+	if err := z.initLBMap(); err != nil {
+		return []string{fmt.Sprintf("%v: describe load balancer failed, err: %v", path, err)}
+	}
+	if _, ok := z.templateLB[id]; ok {
+		return []string{fmt.Sprintf("%v: template load balancer id %v", path, id)}
+	}
+	if !z.isTargetLBId(id) {
+		return []string{fmt.Sprintf("%v: unknown load balancer id %v", path, id)}
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) validateIPGroupRef(path, value string) []string {
+	id, err := strconv.ParseInt(strings.TrimPrefix(value, ipGroupPrefix), 10, 64)
+	if err != nil {
+		return []string{fmt.Sprintf("%v: invalid ip group reference %v", path, value)}
+	}
+	if err = z.initIPGroupMap(); err != nil {
+		return []string{fmt.Sprintf("%v: describe ip group failed, err: %v", path, err)}
+	}
+	if _, ok := z.templateIPGroup[id]; ok {
+		return []string{fmt.Sprintf("%v: template ip group id %v", path, id)}
+	}
+	if !z.isTargetIPGroupId(id) {
+		return []string{fmt.Sprintf("%v: unknown ip group id %v", path, id)}
+	}
+	return nil
+}
+
+// isTargetGroupId 判断源站组Id是否属于目标站点。
+func (z *ZoneCopyManager) isTargetGroupId(id string) bool {
+	for _, v := range z.targetOrigin {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
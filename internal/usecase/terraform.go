@@ -0,0 +1,477 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/pkg/utils"
+)
+
+// tfFailure 无法以Terraform资源表示的对象，写入 .fail.json 文件。
+type tfFailure struct {
+	Type   string      `json:"type"`
+	Name   string      `json:"name"`
+	Reason string      `json:"reason"`
+	Config interface{} `json:"config"`
+}
+
+// tfImport 已渲染资源的地址与站点中实际对象Id的对应关系，用于将已有对象导入Terraform状态。
+type tfImport struct {
+	Address string
+	Id      string
+}
+
+// tfExporter 将站点配置渲染为 tencentcloud_teo_* 资源，对象间的引用使用资源属性表达式而不是Id字面量。
+type tfExporter struct {
+	refs     map[string]string // 模板站点对象Id -> 资源属性表达式
+	names    map[string]bool   // 已使用的资源地址
+	failures []*tfFailure
+	imports  []*tfImport
+	err      error    // 当前对象渲染时遇到的无法表示的引用
+	dropped  []string // 当前对象中资源不支持而未渲染的参数
+}
+
+// tfFieldNames 与SDK字段名蛇形转换结果不一致的资源参数名。
+var tfFieldNames = map[reflect.Type]map[string]string{
+	reflect.TypeOf(teo.Rule{}):              {"Conditions": "or"},
+	reflect.TypeOf(teo.SubRule{}):           {"Conditions": "or"},
+	reflect.TypeOf(teo.RuleAndConditions{}): {"Conditions": "and"},
+	reflect.TypeOf(teo.ZoneSetting{}):       {"CacheConfig": "cache"},
+}
+
+// tfSchemas 各资源支持的参数路径，与 tencentcloud provider 中 tencentcloud_teo_* 资源的参数对应，
+// 路径不在其中的字段不渲染，非空时在 .fail.json 中列出。
+var tfSchemas = map[string]map[string]bool{
+	"tencentcloud_teo_origin_group": tfSchema(
+		"origin_group_name", "origin_type", "configuration_type", "host_header",
+		"origin_records", "origin_records.record", "origin_records.port", "origin_records.weight",
+		"origin_records.proto", "origin_records.area", "origin_records.private",
+		"origin_records.private_parameters", "origin_records.private_parameters.name", "origin_records.private_parameters.value",
+	),
+	"tencentcloud_teo_acceleration_domain": tfSchema(
+		"origin_info", "origin_info.origin_type", "origin_info.origin", "origin_info.backup_origin",
+		"origin_info.private_access", "origin_info.private_parameters",
+		"origin_info.private_parameters.name", "origin_info.private_parameters.value",
+	),
+	"tencentcloud_teo_rule_engine": tfSchema(append(
+		tfRuleSchema("rules"),
+		append(tfRuleSchema("rules.sub_rules.rules"), "rules.sub_rules", "rules.sub_rules.tags")...,
+	)...),
+	"tencentcloud_teo_zone_setting": tfSchema(
+		"cache", "cache.cache", "cache.cache.switch", "cache.cache.cache_time", "cache.cache.ignore_cache_control",
+		"cache.no_cache", "cache.no_cache.switch",
+		"cache.follow_origin", "cache.follow_origin.switch", "cache.follow_origin.default_cache",
+		"cache.follow_origin.default_cache_time", "cache.follow_origin.default_cache_strategy",
+		"cache_key", "cache_key.full_url_cache", "cache_key.ignore_case",
+		"cache_key.query_string", "cache_key.query_string.switch", "cache_key.query_string.action", "cache_key.query_string.value",
+		"max_age", "max_age.follow_origin", "max_age.max_age_time",
+		"offline_cache", "offline_cache.switch",
+		"quic", "quic.switch",
+		"post_max_size", "post_max_size.switch", "post_max_size.max_size",
+		"compression", "compression.switch", "compression.algorithms",
+		"upstream_http2", "upstream_http2.switch",
+		"force_redirect", "force_redirect.switch", "force_redirect.redirect_status_code",
+		"https", "https.http2", "https.ocsp_stapling", "https.tls_version",
+		"https.hsts", "https.hsts.switch", "https.hsts.max_age", "https.hsts.include_sub_domains", "https.hsts.preload",
+		"origin", "origin.origins", "origin.backup_origins", "origin.origin_pull_protocol", "origin.cos_private_access",
+		"smart_routing", "smart_routing.switch",
+		"web_socket", "web_socket.switch", "web_socket.timeout",
+		"client_ip_header", "client_ip_header.switch", "client_ip_header.header_name",
+		"cache_prefresh", "cache_prefresh.switch", "cache_prefresh.percent",
+		"ipv6", "ipv6.switch",
+		"client_ip_country", "client_ip_country.switch", "client_ip_country.header_name",
+		"grpc", "grpc.switch",
+	),
+}
+
+// tfComputed 资源中只读的参数，直接跳过，不作为无法表示的字段列出。
+var tfComputed = map[string]map[string]bool{
+	"tencentcloud_teo_zone_setting": {"area": true},
+}
+
+func tfSchema(paths ...string) map[string]bool {
+	m := make(map[string]bool, len(paths))
+	for _, v := range paths {
+		m[v] = true
+	}
+	return m
+}
+
+// tfRuleSchema 规则及子规则共用的条件与操作参数路径。
+func tfRuleSchema(prefix string) []string {
+	paths := []string{prefix, prefix + ".or", prefix + ".or.and", prefix + ".actions"}
+	for _, v := range []string{"operator", "target", "values", "ignore_case", "name"} {
+		paths = append(paths, prefix+".or.and."+v)
+	}
+	for action, params := range map[string][]string{
+		"normal_action":  {"name", "values"},
+		"rewrite_action": {"action", "name", "values"},
+		"code_action":    {"status_code", "name", "values"},
+	} {
+		base := prefix + ".actions." + action
+		paths = append(paths, base, base+".action", base+".parameters")
+		for _, v := range params {
+			paths = append(paths, base+".parameters."+v)
+		}
+	}
+	return paths
+}
+
+// tfSkipFields 只读或由资源参数单独指定的字段。
+var tfSkipFields = map[string]bool{
+	"ZoneId":        true,
+	"ZoneName":      true,
+	"OriginGroupId": true,
+	"UpdateTime":    true,
+	"RecordId":      true,
+}
+
+var tfNamePattern = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ExportTerraform 将模板站点的源站组、加速域名、规则引擎及站点加速配置导出为Terraform配置，
+// 无法表示的对象写入 .fail.json 文件；同时生成已有对象的 import 块(.import.tf)，以及供 Terraform 1.5 以下版本使用的
+// terraform import 命令(import/ 目录下的 .import.sh)，两者择一使用，返回生成文件的路径。
+func (z *ZoneCopyManager) ExportTerraform(outputDir string) ([]string, error) {
+	tfName, failName, err := utils.GenTencentOutFileName(z.config.TemplateZone, outputDir)
+	if err != nil {
+		return nil, err
+	}
+	e := &tfExporter{
+		refs:  make(map[string]string),
+		names: make(map[string]bool),
+	}
+	var b strings.Builder
+	if err = z.exportTerraformZone(e, &b); err != nil {
+		return nil, err
+	}
+	if err = z.exportTerraformOrigins(e, &b); err != nil {
+		return nil, err
+	}
+	if err = z.exportTerraformDomains(e, &b); err != nil {
+		return nil, err
+	}
+	if err = z.exportTerraformRules(e, &b); err != nil {
+		return nil, err
+	}
+	if err = z.exportTerraformZoneSetting(e, &b); err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(tfName, []byte(b.String()), 0644); err != nil {
+		return nil, err
+	}
+	if e.failures == nil {
+		e.failures = []*tfFailure{}
+	}
+	body, err := json.MarshalIndent(e.failures, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err = os.WriteFile(failName, body, 0644); err != nil {
+		return nil, err
+	}
+	// 脚本放在单独目录，避免与同目录下的 import 块重复导入
+	base := strings.TrimSuffix(filepath.Base(tfName), ".tf")
+	importTf := strings.TrimSuffix(tfName, ".tf") + ".import.tf"
+	importSh := filepath.Join(filepath.Dir(tfName), "import", base+".import.sh")
+	if err = e.writeImports(importTf, importSh); err != nil {
+		return nil, err
+	}
+	return []string{tfName, failName, importTf, importSh}, nil
+}
+
+func (z *ZoneCopyManager) exportTerraformZone(e *tfExporter, b *strings.Builder) error {
+	zone, err := z.zoneImporter.DescribeZone(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	if zone == nil {
+		return fmt.Errorf("zone %v not found", z.config.TemplateZoneId)
+	}
+	planId, err := z.getPlanId(z.config.TemplateZoneId)
+	if err != nil {
+		return err
+	}
+	name := e.resourceName("tencentcloud_teo_zone", *zone.ZoneName)
+	e.refs[*zone.ZoneId] = fmt.Sprintf("tencentcloud_teo_zone.%v.id", name)
+	fmt.Fprintf(b, "resource \"tencentcloud_teo_zone\" %q {\n", name)
+	fmt.Fprintf(b, "  zone_name = %v\n", tfQuote(*zone.ZoneName))
+	fmt.Fprintf(b, "  type      = %v\n", tfQuote(*zone.Type))
+	if zone.Area != nil {
+		fmt.Fprintf(b, "  area      = %v\n", tfQuote(*zone.Area))
+	}
+	fmt.Fprintf(b, "  plan_id   = %v\n", tfQuote(planId))
+	b.WriteString("}\n\n")
+	e.imports = append(e.imports, &tfImport{Address: "tencentcloud_teo_zone." + name, Id: *zone.ZoneId})
+	return nil
+}
+
+func (z *ZoneCopyManager) exportTerraformOrigins(e *tfExporter, b *strings.Builder) error {
+	groups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range groups {
+		name := e.resourceName("tencentcloud_teo_origin_group", *v.OriginGroupName)
+		e.refs[*v.OriginGroupId] = fmt.Sprintf("tencentcloud_teo_origin_group.%v.origin_group_id", name)
+		e.writeResource(b, "tencentcloud_teo_origin_group", name, *v.OriginGroupName, z.tfImportId(*v.OriginGroupId), v, nil)
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) exportTerraformDomains(e *tfExporter, b *strings.Builder) error {
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range domains {
+		name := e.resourceName("tencentcloud_teo_acceleration_domain", *v.DomainName)
+		if v.OriginDetail == nil || v.OriginDetail.OriginType == nil {
+			e.fail("tencentcloud_teo_acceleration_domain", *v.DomainName, "origin detail not found", v)
+			continue
+		}
+		if *v.OriginDetail.OriginType == "LB" {
+			e.fail("tencentcloud_teo_acceleration_domain", *v.DomainName, "load balancer origin is not supported", v)
+			continue
+		}
+		origin := &teo.OriginInfo{
+			OriginType:        v.OriginDetail.OriginType,
+			Origin:            v.OriginDetail.Origin,
+			BackupOrigin:      v.OriginDetail.BackupOrigin,
+			PrivateAccess:     v.OriginDetail.PrivateAccess,
+			PrivateParameters: v.OriginDetail.PrivateParameters,
+		}
+		attrs := []string{"domain_name = " + tfQuote(*v.DomainName)}
+		e.writeResource(b, "tencentcloud_teo_acceleration_domain", name, *v.DomainName, z.tfImportId(*v.DomainName), &struct{ OriginInfo *teo.OriginInfo }{origin}, attrs)
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) exportTerraformRules(e *tfExporter, b *strings.Builder) error {
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	for _, v := range rules {
+		name := e.resourceName("tencentcloud_teo_rule_engine", *v.RuleName)
+		attrs := []string{
+			"rule_name = " + tfQuote(*v.RuleName),
+			"status    = " + tfQuote(*v.Status),
+		}
+		e.writeResource(b, "tencentcloud_teo_rule_engine", name, *v.RuleName, z.tfImportId(*v.RuleId), &struct{ Rules []*teo.Rule }{v.Rules}, attrs)
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) exportTerraformZoneSetting(e *tfExporter, b *strings.Builder) error {
+	sets, err := z.zoneSettingImporter.DescribeZoneSetting(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone setting failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	name := e.resourceName("tencentcloud_teo_zone_setting", z.config.TemplateZone)
+	e.writeResource(b, "tencentcloud_teo_zone_setting", name, z.config.TemplateZone, z.config.TemplateZoneId, sets, nil)
+	return nil
+}
+
+// tfImportId 生成站点下对象的导入Id，格式为 <站点Id>#<对象Id或域名>。
+func (z *ZoneCopyManager) tfImportId(id string) string {
+	return z.config.TemplateZoneId + "#" + id
+}
+
+// writeResource 渲染一个资源，渲染过程中出现无法表示的引用时改为写入失败列表；
+// 资源不支持的字段不渲染，并在失败列表中列出。
+func (e *tfExporter) writeResource(b *strings.Builder, typ, name, desc, importId string, config interface{}, attrs []string) {
+	e.err = nil
+	e.dropped = nil
+	var body strings.Builder
+	fmt.Fprintf(&body, "resource %q %q {\n", typ, name)
+	fmt.Fprintf(&body, "  zone_id = %v\n", e.zoneRef())
+	for _, v := range attrs {
+		fmt.Fprintf(&body, "  %v\n", v)
+	}
+	e.writeStruct(&body, 1, typ, "", reflect.ValueOf(config).Elem())
+	body.WriteString("}\n\n")
+	if e.err != nil {
+		e.fail(typ, desc, e.err.Error(), config)
+		return
+	}
+	if len(e.dropped) > 0 {
+		e.fail(typ, desc, fmt.Sprintf("fields not supported by provider are omitted: %v", e.dropped), config)
+	}
+	b.WriteString(body.String())
+	e.imports = append(e.imports, &tfImport{Address: typ + "." + name, Id: importId})
+}
+
+// writeImports 输出 import 块(Terraform 1.5及以上)及等价的 terraform import 命令，脚本需在配置目录下执行。
+func (e *tfExporter) writeImports(tfName, shName string) error {
+	var blocks, cmds strings.Builder
+	cmds.WriteString("#!/bin/sh\nset -e\n")
+	for _, v := range e.imports {
+		fmt.Fprintf(&blocks, "import {\n  to = %v\n  id = %v\n}\n\n", v.Address, tfQuote(v.Id))
+		fmt.Fprintf(&cmds, "terraform import %v '%v'\n", v.Address, v.Id)
+	}
+	if err := os.WriteFile(tfName, []byte(blocks.String()), 0644); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(shName), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(shName, []byte(cmds.String()), 0755)
+}
+
+// writeStruct 按字段名将SDK结构体渲染为资源参数，标量为属性，结构体为嵌套块；path 为当前块在资源中的参数路径。
+func (e *tfExporter) writeStruct(b *strings.Builder, indent int, typ, path string, v reflect.Value) {
+	pad := strings.Repeat("  ", indent)
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		f, field := t.Field(i), v.Field(i)
+		if !f.IsExported() || tfSkipFields[f.Name] || field.IsZero() {
+			continue
+		}
+		name := tfFieldName(t, f.Name)
+		full := name
+		if path != "" {
+			full = path + "." + name
+		}
+		if tfComputed[typ][full] {
+			continue
+		}
+		if schema, ok := tfSchemas[typ]; ok && !schema[full] {
+			if !stringSet(e.dropped)[full] {
+				e.dropped = append(e.dropped, full)
+			}
+			continue
+		}
+		switch {
+		case field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct:
+			fmt.Fprintf(b, "%v%v {\n", pad, name)
+			e.writeStruct(b, indent+1, typ, full, field.Elem())
+			fmt.Fprintf(b, "%v}\n", pad)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Ptr && field.Type().Elem().Elem().Kind() == reflect.Struct:
+			for j := 0; j < field.Len(); j++ {
+				if field.Index(j).IsNil() {
+					continue
+				}
+				fmt.Fprintf(b, "%v%v {\n", pad, name)
+				e.writeStruct(b, indent+1, typ, full, field.Index(j).Elem())
+				fmt.Fprintf(b, "%v}\n", pad)
+			}
+		case field.Kind() == reflect.Slice:
+			var values []string
+			for j := 0; j < field.Len(); j++ {
+				if !field.Index(j).IsNil() {
+					values = append(values, e.value(field.Index(j).Elem()))
+				}
+			}
+			fmt.Fprintf(b, "%v%v = [%v]\n", pad, name, strings.Join(values, ", "))
+		case field.Kind() == reflect.Ptr:
+			fmt.Fprintf(b, "%v%v = %v\n", pad, name, e.value(field.Elem()))
+		}
+	}
+}
+
+// value 渲染标量值，模板站点对象Id替换为资源属性表达式。
+func (e *tfExporter) value(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if ref, ok := e.refs[s]; ok {
+			return ref
+		}
+		if strings.HasPrefix(s, "lb-") || strings.HasPrefix(s, "eocontent-") {
+			e.err = fmt.Errorf("reference %v has no terraform resource", s)
+		}
+		return tfQuote(s)
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	}
+	e.err = fmt.Errorf("unsupported value type %v", v.Type())
+	return "null"
+}
+
+func (e *tfExporter) zoneRef() string {
+	for _, v := range e.refs {
+		if strings.HasPrefix(v, "tencentcloud_teo_zone.") {
+			return v
+		}
+	}
+	return "null"
+}
+
+func (e *tfExporter) fail(typ, name, reason string, config interface{}) {
+	log.Printf("terraform export %v %v failed, err: %v\n", typ, name, reason)
+	e.failures = append(e.failures, &tfFailure{
+		Type:   typ,
+		Name:   name,
+		Reason: reason,
+		Config: config,
+	})
+}
+
+// resourceName 由对象名称生成唯一的资源名。
+func (e *tfExporter) resourceName(typ, s string) string {
+	name := strings.Trim(tfNamePattern.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "r_" + name
+	}
+	unique := name
+	for i := 2; e.names[typ+"."+unique]; i++ {
+		unique = fmt.Sprintf("%v_%d", name, i)
+	}
+	e.names[typ+"."+unique] = true
+	return unique
+}
+
+// tfFieldName 将SDK字段名转换为资源参数名，如 OriginGroupName -> origin_group_name。
+func tfFieldName(t reflect.Type, name string) string {
+	if m, ok := tfFieldNames[t]; ok {
+		if v, ok := m[name]; ok {
+			return v
+		}
+	}
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		upper := r >= 'A' && r <= 'Z'
+		if upper && i > 0 {
+			prev := runes[i-1]
+			prevLower := (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9')
+			nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+			prevUpper := prev >= 'A' && prev <= 'Z'
+			if prevLower || (prevUpper && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteString(strings.ToLower(string(r)))
+	}
+	return b.String()
+}
+
+// tfQuote 生成HCL字符串字面量，转义模板插值符号。
+func tfQuote(s string) string {
+	s = strconv.Quote(s)
+	s = strings.ReplaceAll(s, "${", "$${")
+	return strings.ReplaceAll(s, "%{", "%%{")
+}
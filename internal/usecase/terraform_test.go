@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+func TestTfFieldName(t *testing.T) {
+	cases := []struct {
+		typ  reflect.Type
+		name string
+		want string
+	}{
+		{reflect.TypeOf(teo.OriginGroup{}), "OriginGroupName", "origin_group_name"},
+		{reflect.TypeOf(teo.Https{}), "Http2", "http2"},
+		{reflect.TypeOf(teo.ZoneSetting{}), "CacheConfig", "cache"},
+		{reflect.TypeOf(teo.ZoneSetting{}), "Ipv6", "ipv6"},
+		{reflect.TypeOf(teo.Rule{}), "Conditions", "or"},
+		{reflect.TypeOf(teo.RuleAndConditions{}), "Conditions", "and"},
+	}
+	for _, c := range cases {
+		if got := tfFieldName(c.typ, c.name); got != c.want {
+			t.Errorf("tfFieldName(%v, %q) = %q, want %q", c.typ, c.name, got, c.want)
+		}
+	}
+}
+
+func TestWriteResourceSchema(t *testing.T) {
+	sets := &teo.ZoneSetting{
+		Area:          common.StringPtr("global"),
+		CacheConfig:   &teo.CacheConfig{NoCache: &teo.NoCache{Switch: common.StringPtr("on")}},
+		Quic:          &teo.Quic{Switch: common.StringPtr("on")},
+		ImageOptimize: &teo.ImageOptimize{Switch: common.StringPtr("on")},
+	}
+	e := &tfExporter{refs: make(map[string]string), names: make(map[string]bool)}
+	var b strings.Builder
+	e.writeResource(&b, "tencentcloud_teo_zone_setting", "z", "z", "zone-1", sets, nil)
+	out := b.String()
+	for _, v := range []string{"  cache {\n    no_cache {\n      switch = \"on\"", "  quic {\n    switch = \"on\""} {
+		if !strings.Contains(out, v) {
+			t.Errorf("output missing %q:\n%v", v, out)
+		}
+	}
+	for _, v := range []string{"area", "image_optimize", "cache_config"} {
+		if strings.Contains(out, v) {
+			t.Errorf("output should not contain %q:\n%v", v, out)
+		}
+	}
+	if len(e.failures) != 1 || !strings.Contains(e.failures[0].Reason, "image_optimize") || strings.Contains(e.failures[0].Reason, "area") {
+		t.Errorf("failures = %+v, want only image_optimize reported", e.failures)
+	}
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// varPattern 模板站点配置值中的变量占位符，形如 ${backend}。
+var varPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_]+)\}`)
+
+// resolveVars 使用目标站点配置中的vars替换变量占位符，未定义的变量保持原样，导入前由 CheckVars 拦截。
+func (z *ZoneCopyManager) resolveVars(old string) string {
+	return varPattern.ReplaceAllStringFunc(old, func(s string) string {
+		name := varPattern.FindStringSubmatch(s)[1]
+		v, ok := z.config.Vars[name]
+		if !ok {
+			log.Printf("zoneId: %v var %v is not defined, keep it unchanged\n", z.config.TargetZoneId, name)
+			return s
+		}
+		return v
+	})
+}
+
+// resolveActionVars 替换规则操作参数值中的变量占位符。
+func (z *ZoneCopyManager) resolveActionVars(action *teo.Action) {
+	var values [][]*string
+	switch {
+	case action.NormalAction != nil:
+		for _, v := range action.NormalAction.Parameters {
+			values = append(values, v.Values)
+		}
+	case action.RewriteAction != nil:
+		for _, v := range action.RewriteAction.Parameters {
+			values = append(values, v.Values)
+		}
+	case action.CodeAction != nil:
+		for _, v := range action.CodeAction.Parameters {
+			values = append(values, v.Values)
+		}
+	}
+	for _, vs := range values {
+		for k := range vs {
+			if vs[k] != nil {
+				vs[k] = common.StringPtr(z.resolveVars(*vs[k]))
+			}
+		}
+	}
+}
+
+// CheckVars 检查模板站点源站组、加速域名及规则引擎中引用的变量是否均已定义，存在未定义的变量时返回错误，需在写入目标站点前调用。
+func (z *ZoneCopyManager) CheckVars() error {
+	groups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	configs, err := z.domainImporter.DescribeDomainConfigList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain config failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	names, err := undefinedVars(z.config.Vars, groups, domains, configs, rules)
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		return fmt.Errorf("vars %v are referenced by template zone but not defined in config", names)
+	}
+	return nil
+}
+
+// undefinedVars 返回对象中引用但未在vars中定义的变量名，按名称排序。
+func undefinedVars(vars map[string]string, objs ...interface{}) ([]string, error) {
+	seen := make(map[string]bool)
+	var names []string
+	for _, v := range objs {
+		body, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range varPattern.FindAllStringSubmatch(string(body), -1) {
+			if _, ok := vars[m[1]]; ok || seen[m[1]] {
+				continue
+			}
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
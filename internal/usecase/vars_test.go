@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/domain/entity"
+)
+
+func TestResolveVars(t *testing.T) {
+	z := &ZoneCopyManager{
+		config: &entity.ZoneCopyConfig{
+			Vars: map[string]string{"backend": "origin.brand-a.com", "brand": "a"},
+		},
+	}
+	cases := []struct {
+		old, want string
+	}{
+		{"${backend}", "origin.brand-a.com"},
+		{"x-brand-${brand}:${backend}", "x-brand-a:origin.brand-a.com"},
+		{"${missing}", "${missing}"},
+		{"$backend", "$backend"},
+		{"plain.com", "plain.com"},
+	}
+	for _, c := range cases {
+		if got := z.resolveVars(c.old); got != c.want {
+			t.Errorf("resolveVars(%q) = %q, want %q", c.old, got, c.want)
+		}
+	}
+}
+
+func TestUndefinedVars(t *testing.T) {
+	vars := map[string]string{"backend": "origin.brand-a.com"}
+	rule := &teo.RuleItem{
+		RuleName: common.StringPtr("r"),
+		Rules: []*teo.Rule{{
+			Conditions: []*teo.RuleAndConditions{{
+				Conditions: []*teo.RuleCondition{{
+					Target: common.StringPtr("url"),
+					Values: common.StringPtrs([]string{"/${path}"}),
+				}},
+			}},
+		}},
+	}
+	cases := []struct {
+		name string
+		objs []interface{}
+		want []string
+	}{
+		{"all defined", []interface{}{map[string]string{"Origin": "${backend}"}}, nil},
+		{"condition value", []interface{}{rule}, []string{"path"}},
+		{"dedup and sort", []interface{}{[]string{"${b}", "${a}", "${b}", "${backend}"}}, []string{"a", "b"}},
+	}
+	for _, c := range cases {
+		got, err := undefinedVars(vars, c.objs...)
+		if err != nil {
+			t.Fatalf("%v: undefinedVars() err: %v", c.name, err)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: undefinedVars() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+	"zonecopy/internal/repository"
+)
+
+// ZoneSnapshot 模板站点配置快照，记录各对象配置的摘要，用于计算两次快照之间的变更。
+type ZoneSnapshot struct {
+	ZoneId       string            `json:"zone_id"`
+	OriginGroups map[string]string `json:"origin_groups"` // 源站组名称 -> 配置摘要
+	Domains      map[string]string `json:"domains"`       // 域名 -> 配置摘要
+	Rules        map[string]string `json:"rules"`         // 规则名称 -> 配置摘要
+	ZoneSetting  string            `json:"zone_setting"`  // 站点加速配置摘要
+}
+
+// ZoneChanges 两次快照之间新增或修改的对象，以及已删除的对象。
+type ZoneChanges struct {
+	OriginGroups        []string
+	Domains             []string
+	Rules               []string
+	ZoneSetting         bool
+	RemovedOriginGroups []string
+	RemovedDomains      []string
+	RemovedRules        []string
+}
+
+// Empty 判断是否存在变更。
+func (c *ZoneChanges) Empty() bool {
+	return len(c.OriginGroups) == 0 && len(c.Domains) == 0 && len(c.Rules) == 0 && !c.ZoneSetting &&
+		len(c.RemovedOriginGroups) == 0 && len(c.RemovedDomains) == 0 && len(c.RemovedRules) == 0
+}
+
+// WatchState watch 保存的同步状态，按目标站点分别记录上次同步成功时的模板站点快照。
+type WatchState struct {
+	Targets map[string]*ZoneSnapshot `json:"targets"` // 目标站点Id -> 快照
+}
+
+// LoadWatchState 读取上次保存的同步状态，文件不存在时返回空状态。
+func LoadWatchState(path string) (*WatchState, error) {
+	s := &WatchState{Targets: make(map[string]*ZoneSnapshot)}
+	body, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(body, s); err != nil {
+		return nil, err
+	}
+	if s.Targets == nil {
+		s.Targets = make(map[string]*ZoneSnapshot)
+	}
+	return s, nil
+}
+
+// Save 保存同步状态。
+func (s *WatchState) Save(path string) error {
+	body, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, body, 0644)
+}
+
+// Snapshot 通过各模块的查询接口生成模板站点源站组、加速域名、规则引擎及站点加速配置的快照。
+func (z *ZoneCopyManager) Snapshot() (*ZoneSnapshot, error) {
+	s := &ZoneSnapshot{
+		ZoneId:       z.config.TemplateZoneId,
+		OriginGroups: make(map[string]string),
+		Domains:      make(map[string]string),
+		Rules:        make(map[string]string),
+	}
+	groups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	for _, v := range groups {
+		s.OriginGroups[*v.OriginGroupName] = digest(originGroupState(v))
+	}
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	configs, err := z.domainConfigs(z.config.TemplateZoneId)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range domains {
+		s.Domains[*v.DomainName] = digest([]interface{}{v.OriginDetail, configs[*v.DomainName]})
+	}
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	for _, v := range rules {
+		s.Rules[*v.RuleName] = digest(&teo.RuleItem{Status: v.Status, Rules: v.Rules, Tags: v.Tags})
+	}
+	sets, err := z.zoneSettingImporter.DescribeZoneSettingRaw(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone setting failed, err: %v\n", z.config.TemplateZoneId, err)
+		return nil, err
+	}
+	s.ZoneSetting = digest(zoneSettingSections(sets))
+	return s, nil
+}
+
+// DiffSnapshot 计算从old到nw的变更。
+func DiffSnapshot(old, nw *ZoneSnapshot) *ZoneChanges {
+	c := &ZoneChanges{}
+	c.OriginGroups, c.RemovedOriginGroups = diffDigests(old.OriginGroups, nw.OriginGroups)
+	c.Domains, c.RemovedDomains = diffDigests(old.Domains, nw.Domains)
+	c.Rules, c.RemovedRules = diffDigests(old.Rules, nw.Rules)
+	c.ZoneSetting = old.ZoneSetting != nw.ZoneSetting
+	return c
+}
+
+func diffDigests(old, nw map[string]string) ([]string, []string) {
+	var changed, removed []string
+	for k, v := range nw {
+		if old[k] != v {
+			changed = append(changed, k)
+		}
+	}
+	for k := range old {
+		if _, ok := nw[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(removed)
+	return changed, removed
+}
+
+// SyncChanges 将模板站点中新增或修改的对象同步到目标站点，目标站点已存在的对象按名称覆盖修改。
+// 已删除的对象不做处理，仅通过notify提示。
+func (z *ZoneCopyManager) SyncChanges(c *ZoneChanges, notify func(msg string)) error {
+	if len(c.OriginGroups) > 0 {
+		if err := z.syncOriginGroups(c.OriginGroups, notify); err != nil {
+			return err
+		}
+	}
+	if len(c.Domains) > 0 {
+		if err := z.syncDomains(c.Domains, notify); err != nil {
+			return err
+		}
+	}
+	if len(c.Rules) > 0 {
+		if err := z.syncRules(c.Rules, notify); err != nil {
+			return err
+		}
+	}
+	if c.ZoneSetting {
+		skipped, err := z.ImportZoneSetting()
+		if err != nil {
+			return err
+		}
+		notify("zone setting synced")
+		if len(skipped) > 0 {
+			notify(fmt.Sprintf("zone setting %v can not be synced", skipped))
+		}
+	}
+	for _, v := range c.RemovedOriginGroups {
+		notify(fmt.Sprintf("origin group %v was removed from template, not synced", v))
+	}
+	for _, v := range c.RemovedDomains {
+		notify(fmt.Sprintf("domain %v was removed from template, not synced", v))
+	}
+	for _, v := range c.RemovedRules {
+		notify(fmt.Sprintf("rule %v was removed from template, not synced", v))
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) syncOriginGroups(names []string, notify func(msg string)) error {
+	// 新建的源站组需在后续域名及规则同步时重新建立映射
+	defer z.resetOriginMap()
+	groups, err := z.originImporter.DescribeOriginGroupList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe origin group failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	selected := stringSet(names)
+	for _, v := range groups {
+		if !selected[*v.OriginGroupName] {
+			continue
+		}
+		req := z.buildOriginGroupRequest(v)
+		id, err := z.originImporter.GetOriginIdByName(z.config.TargetZoneId, *req.OriginGroupName)
+		if err != nil {
+			log.Printf("origin group: %v describe failed, err: %v\n", *req.OriginGroupName, err)
+			return err
+		}
+		if id == "" {
+			_, err = z.originImporter.CreateOrigin(req)
+		} else {
+			m := teo.NewModifyOriginGroupRequest()
+			m.ZoneId = req.ZoneId
+			m.OriginGroupId = common.StringPtr(id)
+			m.OriginType = req.OriginType
+			m.OriginGroupName = req.OriginGroupName
+			m.ConfigurationType = req.ConfigurationType
+			m.OriginRecords = req.OriginRecords
+			m.HostHeader = req.HostHeader
+			err = z.originImporter.ModifyOrigin(m)
+		}
+		if err != nil {
+			log.Printf("origin group: %v sync failed, err: %v\n", *req.OriginGroupName, err)
+			return err
+		}
+		notify(fmt.Sprintf("origin group %v synced", *req.OriginGroupName))
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) syncDomains(names []string, notify func(msg string)) error {
+	domains, err := z.domainImporter.DescribeDomainListDetail(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	configs, err := z.domainConfigs(z.config.TemplateZoneId)
+	if err != nil {
+		return err
+	}
+	selected := stringSet(names)
+	for _, v := range domains {
+		if !selected[*v.DomainName] {
+			continue
+		}
+		req := teo.NewCreateAccelerationDomainRequest()
+		req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+		req.DomainName = common.StringPtr(z.getNewName(*v.DomainName))
+		if req.OriginInfo, err = z.converDomainOrigin(v.OriginDetail); err != nil {
+			log.Printf("domain：%v -> %v convert config failed， err: %v\n", *v.DomainName, *req.DomainName, err)
+			return err
+		}
+		z.applyDomainOriginOverride(*req.DomainName, req.OriginInfo)
+		exist, err := z.domainImporter.IsDomainExist(*req.ZoneId, *req.DomainName)
+		if err != nil {
+			log.Printf("domain：%v -> %v check exist failed， err: %v\n", *v.DomainName, *req.DomainName, err)
+			return err
+		}
+		if !exist {
+			err = z.domainImporter.CreateDomain(req)
+		} else {
+			m := teo.NewModifyAccelerationDomainRequest()
+			m.ZoneId = req.ZoneId
+			m.DomainName = req.DomainName
+			m.OriginInfo = req.OriginInfo
+			err = z.domainImporter.ModifyDomain(m)
+		}
+		if err != nil {
+			log.Printf("domain：%v -> %v sync failed， err: %v\n", *v.DomainName, *req.DomainName, err)
+			return err
+		}
+		if err = z.importDomainConfig(configs[*v.DomainName], req); err != nil {
+			log.Printf("domain：%v -> %v sync config failed， err: %v\n", *v.DomainName, *req.DomainName, err)
+			return err
+		}
+		notify(fmt.Sprintf("domain %v synced", *req.DomainName))
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) syncRules(names []string, notify func(msg string)) error {
+	rules, err := z.ruleImporter.DescribeRuleList(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	current, err := z.ruleImporter.DescribeRuleList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	existing := make(map[string]*teo.RuleItem)
+	for _, v := range current {
+		existing[*v.RuleName] = v
+	}
+	selected := stringSet(names)
+	created := make(map[string]bool)
+	// 逆序导入，新建规则的展示顺序和原站点一致
+	for i := len(rules) - 1; i >= 0; i-- {
+		v := rules[i]
+		if !selected[*v.RuleName] {
+			continue
+		}
+		req := teo.NewCreateRuleRequest()
+		req.ZoneId = common.StringPtr(z.config.TargetZoneId)
+		req.RuleName = common.StringPtr(z.getNewName(*v.RuleName))
+		req.Status = common.StringPtr("enable")
+		if req.Rules, err = z.convertRules(v.Rules); err != nil {
+			log.Printf("rule name: %v convert config failed, err: %v\n", *req.RuleName, err)
+			return err
+		}
+		req.Tags = v.Tags
+		if err = z.validateRuleRequest(req); err != nil {
+			log.Printf("rule name: %v validate failed, err: %v\n", *req.RuleName, err)
+			return err
+		}
+		if old, ok := existing[*req.RuleName]; !ok {
+			err = z.ruleImporter.CreateRule(req)
+			created[*req.RuleName] = true
+		} else {
+			// 保留目标站点中规则的启用状态
+			m := teo.NewModifyRuleRequest()
+			m.ZoneId = req.ZoneId
+			m.RuleId = old.RuleId
+			m.RuleName = req.RuleName
+			m.Status = old.Status
+			m.Rules = req.Rules
+			m.Tags = req.Tags
+			err = z.ruleImporter.ModifyRule(m)
+		}
+		if err != nil {
+			log.Printf("rule name: %v sync failed, err: %v\n", *req.RuleName, err)
+			return err
+		}
+		notify(fmt.Sprintf("rule %v synced", *req.RuleName))
+	}
+	if len(created) == 0 {
+		return nil
+	}
+	return z.placeNewRules(rules, created)
+}
+
+// placeNewRules 新建的规则默认排在最前，按模板站点中的顺序移动到前一条规则之后。
+func (z *ZoneCopyManager) placeNewRules(template []*teo.RuleItem, created map[string]bool) error {
+	current, err := z.ruleImporter.DescribeRuleList(z.config.TargetZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe rule list failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	ids := make(map[string]string, len(current))
+	var names []string
+	for _, v := range current {
+		ids[*v.RuleName] = *v.RuleId
+		names = append(names, *v.RuleName)
+	}
+	var order []string
+	for _, v := range template {
+		order = append(order, z.getNewName(*v.RuleName))
+	}
+	placed := placeRules(names, order, created)
+	if sameConfig(placed, names) {
+		return nil
+	}
+	ruleIds := make([]string, 0, len(placed))
+	for _, v := range placed {
+		ruleIds = append(ruleIds, ids[v])
+	}
+	if err = z.ruleImporter.ModifyRulePriority(z.config.TargetZoneId, ruleIds); err != nil {
+		log.Printf("zone id: %v modify rule priority failed, err: %v\n", z.config.TargetZoneId, err)
+		return err
+	}
+	return nil
+}
+
+// placeRules 将 created 中的规则按 template 的顺序插入到其在模板中前一条规则之后，没有前一条规则时置于最前，
+// 其余规则保持 target 中的相对顺序。
+func placeRules(target, template []string, created map[string]bool) []string {
+	present := stringSet(target)
+	var placed []string
+	for _, v := range target {
+		if !created[v] {
+			placed = append(placed, v)
+		}
+	}
+	prev := ""
+	for _, v := range template {
+		if !present[v] {
+			continue
+		}
+		if created[v] {
+			pos := 0
+			for i, p := range placed {
+				if p == prev {
+					pos = i + 1
+					break
+				}
+			}
+			placed = append(placed[:pos], append([]string{v}, placed[pos:]...)...)
+		}
+		prev = v
+	}
+	return placed
+}
+
+// domainConfigs 查询站点下全部加速域名的域名级配置，按域名索引。
+func (z *ZoneCopyManager) domainConfigs(zoneId string) (map[string]*repository.DomainConfig, error) {
+	configs, err := z.domainImporter.DescribeDomainConfigList(zoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe domain config failed, err: %v\n", zoneId, err)
+		return nil, err
+	}
+	m := make(map[string]*repository.DomainConfig)
+	for _, v := range configs {
+		m[*v.DomainName] = v
+	}
+	return m, nil
+}
+
+func digest(v interface{}) string {
+	body, _ := json.Marshal(v)
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func stringSet(values []string) map[string]bool {
+	m := make(map[string]bool, len(values))
+	for _, v := range values {
+		m[v] = true
+	}
+	return m
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshot(t *testing.T) {
+	old := &ZoneSnapshot{
+		OriginGroups: map[string]string{"og-a": "1", "og-b": "2"},
+		Domains:      map[string]string{"a.example.com": "1"},
+		Rules:        map[string]string{"rule-a": "1", "rule-b": "2"},
+		ZoneSetting:  "1",
+	}
+	cases := []struct {
+		name string
+		nw   *ZoneSnapshot
+		want *ZoneChanges
+	}{
+		{
+			name: "unchanged",
+			nw:   old,
+			want: &ZoneChanges{},
+		},
+		{
+			name: "added modified and removed",
+			nw: &ZoneSnapshot{
+				OriginGroups: map[string]string{"og-a": "1", "og-b": "3", "og-c": "1"},
+				Domains:      map[string]string{},
+				Rules:        map[string]string{"rule-b": "2"},
+				ZoneSetting:  "2",
+			},
+			want: &ZoneChanges{
+				OriginGroups:   []string{"og-b", "og-c"},
+				ZoneSetting:    true,
+				RemovedDomains: []string{"a.example.com"},
+				RemovedRules:   []string{"rule-a"},
+			},
+		},
+	}
+	for _, c := range cases {
+		got := DiffSnapshot(old, c.nw)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: DiffSnapshot() = %+v, want %+v", c.name, got, c.want)
+		}
+		if got.Empty() != (c.name == "unchanged") {
+			t.Errorf("%v: Empty() = %v", c.name, got.Empty())
+		}
+	}
+}
+
+func TestPlaceRules(t *testing.T) {
+	cases := []struct {
+		name     string
+		target   []string
+		template []string
+		created  []string
+		want     []string
+	}{
+		{
+			name:     "nothing created",
+			target:   []string{"a", "b"},
+			template: []string{"a", "b"},
+			want:     []string{"a", "b"},
+		},
+		{
+			name:     "created in the middle",
+			target:   []string{"b", "a", "c"},
+			template: []string{"a", "b", "c"},
+			created:  []string{"b"},
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "created first",
+			target:   []string{"x", "a"},
+			template: []string{"x", "a"},
+			created:  []string{"x"},
+			want:     []string{"x", "a"},
+		},
+		{
+			name:     "consecutive created after target only rule",
+			target:   []string{"c", "d", "own", "a", "b"},
+			template: []string{"a", "b", "c", "d"},
+			created:  []string{"c", "d"},
+			want:     []string{"own", "a", "b", "c", "d"},
+		},
+		{
+			name:     "previous template rule missing in target",
+			target:   []string{"c", "a"},
+			template: []string{"a", "b", "c"},
+			created:  []string{"c"},
+			want:     []string{"a", "c"},
+		},
+	}
+	for _, c := range cases {
+		got := placeRules(c.target, c.template, stringSet(c.created))
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: placeRules() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestWatchState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, err := LoadWatchState(path)
+	if err != nil || len(s.Targets) != 0 {
+		t.Fatalf("LoadWatchState() on missing file = %+v, %v", s, err)
+	}
+	s.Targets["zone-1"] = &ZoneSnapshot{ZoneId: "zone-t", ZoneSetting: "1"}
+	if err = s.Save(path); err != nil {
+		t.Fatalf("Save() err: %v", err)
+	}
+	got, err := LoadWatchState(path)
+	if err != nil {
+		t.Fatalf("LoadWatchState() err: %v", err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("LoadWatchState() = %+v, want %+v", got.Targets["zone-1"], s.Targets["zone-1"])
+	}
+}
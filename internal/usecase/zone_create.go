@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/common"
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+const (
+	defaultZonePollInterval = 30 // 秒
+	defaultZoneTimeout      = 60 // 分钟
+)
+
+// CreateTargetZone 按模板站点的接入方式创建目标站点并绑定配置的套餐，等待站点生效后返回。
+// 需要用户手动添加的NS或归属权校验记录通过notify输出。
+func (z *ZoneCopyManager) CreateTargetZone(notify func(msg string)) error {
+	cfg := z.config.CreateZone
+	// 创建站点前检查，避免站点创建后绑定套餐失败遗留未绑定套餐的站点
+	if cfg.PlanId == "" {
+		return fmt.Errorf("create_zone.plan_id is required")
+	}
+	if z.config.TargetZoneId != "" {
+		return fmt.Errorf("target_zone_id %v is configured, remove it or create_zone", z.config.TargetZoneId)
+	}
+	tpl, err := z.zoneImporter.DescribeZone(z.config.TemplateZoneId)
+	if err != nil {
+		log.Printf("zone id: %v describe zone failed, err: %v\n", z.config.TemplateZoneId, err)
+		return err
+	}
+	if tpl == nil {
+		return fmt.Errorf("template zone %v not found", z.config.TemplateZoneId)
+	}
+	zone, err := z.zoneImporter.DescribeZoneByName(z.config.TargetZone)
+	if err != nil {
+		log.Printf("zone name: %v describe zone failed, err: %v\n", z.config.TargetZone, err)
+		return err
+	}
+	if zone == nil {
+		// CNAME接入需先完成站点归属权校验
+		if *tpl.Type == "partial" {
+			if err = z.identifyTargetZone(notify); err != nil {
+				return err
+			}
+		}
+		req := teo.NewCreateZoneRequest()
+		req.ZoneName = common.StringPtr(z.config.TargetZone)
+		req.Type = tpl.Type
+		id, err := z.zoneImporter.CreateZone(req)
+		if err != nil {
+			log.Printf("zone name: %v create zone failed, err: %v\n", z.config.TargetZone, err)
+			return err
+		}
+		if zone, err = z.zoneImporter.DescribeZone(id); err != nil {
+			log.Printf("zone id: %v describe zone failed, err: %v\n", id, err)
+			return err
+		}
+		if zone == nil {
+			return fmt.Errorf("zone %v not found after creation", id)
+		}
+	}
+	z.config.TargetZoneId = *zone.ZoneId
+	if len(zone.Resources) == 0 {
+		if err = z.zoneImporter.BindZoneToPlan(*zone.ZoneId, cfg.PlanId); err != nil {
+			log.Printf("zone id: %v bind plan %v failed, err: %v\n", *zone.ZoneId, cfg.PlanId, err)
+			return err
+		}
+	}
+	if *zone.Type == "full" && !isZoneActive(zone) {
+		notify(fmt.Sprintf("please change the name servers of %v to: %v", z.config.TargetZone, strings.Join(common.StringValues(zone.NameServers), ", ")))
+	}
+	return z.waitZone(func() (bool, error) {
+		zone, err := z.zoneImporter.DescribeZone(z.config.TargetZoneId)
+		if err != nil {
+			return false, err
+		}
+		return zone != nil && isZoneActive(zone), nil
+	})
+}
+
+// identifyTargetZone 发起目标站点的归属权校验并等待校验通过。
+func (z *ZoneCopyManager) identifyTargetZone(notify func(msg string)) error {
+	ok, err := z.zoneImporter.IsZoneIdentified(z.config.TargetZone)
+	if err != nil {
+		log.Printf("zone name: %v describe identification failed, err: %v\n", z.config.TargetZone, err)
+		return err
+	}
+	if ok {
+		return nil
+	}
+	record, err := z.zoneImporter.IdentifyZone(z.config.TargetZone)
+	if err != nil {
+		log.Printf("zone name: %v identify zone failed, err: %v\n", z.config.TargetZone, err)
+		return err
+	}
+	notify(fmt.Sprintf("please add dns record to verify %v: %v %v %v", z.config.TargetZone,
+		*record.Subdomain, *record.RecordType, *record.RecordValue))
+	return z.waitZone(func() (bool, error) {
+		return z.zoneImporter.IsZoneIdentified(z.config.TargetZone)
+	})
+}
+
+// waitZone 轮询直到done返回true或超时。
+func (z *ZoneCopyManager) waitZone(done func() (bool, error)) error {
+	interval, timeout := defaultZonePollInterval, defaultZoneTimeout
+	if cfg := z.config.CreateZone; cfg != nil && cfg.PollInterval > 0 {
+		interval = cfg.PollInterval
+	}
+	if cfg := z.config.CreateZone; cfg != nil && cfg.Timeout > 0 {
+		timeout = cfg.Timeout
+	}
+	deadline := time.Now().Add(time.Duration(timeout) * time.Minute)
+	for {
+		ok, err := done()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("zone %v is not active after %v minutes", z.config.TargetZone, timeout)
+		}
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
+
+// isZoneActive 判断站点是否已生效，NS接入需NS已切换，CNAME接入需归属权已校验。
+func isZoneActive(zone *teo.Zone) bool {
+	if zone.Type != nil && *zone.Type == "partial" {
+		return zone.CnameStatus != nil && *zone.CnameStatus == "finished"
+	}
+	return zone.Status != nil && *zone.Status == "active"
+}
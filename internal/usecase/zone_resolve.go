@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+
+	teo "github.com/tencentcloud/tencentcloud-sdk-go/tencentcloud/teo/v20220901"
+)
+
+// ResolveZones 补全配置中缺失的站点名称或站点Id，两者均填写时校验是否匹配，并校验站点已生效。
+// 配置了创建目标站点时，目标站点由CreateTargetZone处理。
+func (z *ZoneCopyManager) ResolveZones() error {
+	if err := z.resolveZone(&z.config.TemplateZone, &z.config.TemplateZoneId); err != nil {
+		log.Printf("template zone: %v(%v) resolve failed, err: %v\n", z.config.TemplateZone, z.config.TemplateZoneId, err)
+		return fmt.Errorf("template zone: %v", err)
+	}
+	if z.config.CreateZone != nil {
+		return nil
+	}
+	return z.ResolveTargetZone()
+}
+
+// ResolveTargetZone 仅补全并校验目标站点，用于不依赖模板站点的命令。
+func (z *ZoneCopyManager) ResolveTargetZone() error {
+	if err := z.resolveZone(&z.config.TargetZone, &z.config.TargetZoneId); err != nil {
+		log.Printf("target zone: %v(%v) resolve failed, err: %v\n", z.config.TargetZone, z.config.TargetZoneId, err)
+		return fmt.Errorf("target zone: %v", err)
+	}
+	return nil
+}
+
+func (z *ZoneCopyManager) resolveZone(name, id *string) error {
+	var err error
+	var zone *teo.Zone
+	if *id != "" {
+		zone, err = z.zoneImporter.DescribeZone(*id)
+	} else {
+		zone, err = z.zoneImporter.DescribeZoneByName(*name)
+	}
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return fmt.Errorf("zone %v%v not found", *name, *id)
+	}
+	if *name != "" && *name != *zone.ZoneName {
+		return fmt.Errorf("zone id %v belongs to %v, not %v", *zone.ZoneId, *zone.ZoneName, *name)
+	}
+	if !isZoneActive(zone) {
+		return fmt.Errorf("zone %v(%v) is not active", *zone.ZoneName, *zone.ZoneId)
+	}
+	*name, *id = *zone.ZoneName, *zone.ZoneId
+	return nil
+}